@@ -17,9 +17,15 @@
 package abi
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
+	"reflect"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
 // The ABI holds information about a contract's context and available
@@ -29,6 +35,13 @@ type ABI struct {
 	Constructor Method
 	Methods     map[string]Method
 	Events      map[string]Event
+	Errors      map[string]Error
+
+	// Fallback and Receive are the unnamed functions executed when no
+	// method matches the call data or when plain ether is sent. Their Name
+	// is empty if the contract doesn't define them.
+	Fallback Method
+	Receive  Method
 }
 
 // JSON returns a parsed ABI interface and error if it failed.
@@ -55,7 +68,7 @@ func (abi ABI) Pack(name string, args ...interface{}) ([]byte, error) {
 	if name == "" {
 		method = abi.Constructor
 	} else {
-		m, exist := abi.Methods[name]
+		m, exist := abi.method(name)
 		if !exist {
 			return nil, fmt.Errorf("method '%s' not found", name)
 		}
@@ -72,7 +85,47 @@ func (abi ABI) Pack(name string, args ...interface{}) ([]byte, error) {
 	return append(method.Id(), arguments...), nil
 }
 
+// method looks up a method by its key in Methods or by its signature,
+// e.g. `transfer(address,uint256)`.
+func (abi ABI) method(name string) (Method, bool) {
+	if method, ok := abi.Methods[name]; ok {
+		return method, true
+	}
+	method, err := abi.MethodBySignature(name)
+	return method, err == nil
+}
+
+// MethodBySignature looks up a method by its string signature, e.g.
+// `transfer(address,uint256)`. This is the way to address a specific
+// overload of a method.
+func (abi ABI) MethodBySignature(sig string) (Method, error) {
+	for _, method := range abi.Methods {
+		if method.Sig() == sig {
+			return method, nil
+		}
+	}
+	return Method{}, fmt.Errorf("no method with signature %s", sig)
+}
+
+// MethodById looks up a method by the 4-byte selector at the beginning of
+// the given call data.
+func (abi ABI) MethodById(sigdata []byte) (Method, error) {
+	if len(sigdata) < 4 {
+		return Method{}, fmt.Errorf("data too short (%d bytes) for abi method lookup", len(sigdata))
+	}
+	for _, method := range abi.Methods {
+		if bytes.Equal(method.Id(), sigdata[:4]) {
+			return method, nil
+		}
+	}
+	return Method{}, fmt.Errorf("no method with id: %#x", sigdata[:4])
+}
+
 // Unpack output in v according to the abi specification
+//
+// Multiple return values can be unpacked into a struct: each value goes to
+// the field tagged `abi:"<name>"` or else to the field named like the
+// camel-cased output name (`_to` and `to` both go to To).
 func (abi ABI) Unpack(v interface{}, name string, output []byte) (err error) {
 	if err = bytesAreProper(output); err != nil {
 		return err
@@ -80,7 +133,7 @@ func (abi ABI) Unpack(v interface{}, name string, output []byte) (err error) {
 	// since there can't be naming collisions with contracts and events,
 	// we need to decide whether we're calling a method or an event
 	var unpack unpacker
-	if method, ok := abi.Methods[name]; ok {
+	if method, ok := abi.method(name); ok {
 		unpack = method
 	} else if event, ok := abi.Events[name]; ok {
 		unpack = event
@@ -95,15 +148,194 @@ func (abi ABI) Unpack(v interface{}, name string, output []byte) (err error) {
 	return unpack.singleUnpack(v, output)
 }
 
+// UnpackInput decodes the call data of a transaction calling the named
+// method, selector included, into its arguments keyed by name, or
+// `arg<index>` for unnamed arguments.
+func (abi ABI) UnpackInput(name string, data []byte) (map[string]interface{}, error) {
+	method, ok := abi.method(name)
+	if !ok {
+		return nil, fmt.Errorf("abi: could not locate named method %s", name)
+	}
+	if len(data) < 4 || !bytes.Equal(data[:4], method.Id()) {
+		return nil, fmt.Errorf("abi: call data doesn't call %s", method.Sig())
+	}
+	values, err := unpackArguments(method.Inputs, data[4:])
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]interface{}, len(values))
+	for i, value := range values {
+		result[ArgumentName(method.Inputs, i)] = value
+	}
+	return result, nil
+}
+
+// EventById looks up an event by its id, the first topic of its logs.
+func (abi ABI) EventById(topic common.Hash) (Event, error) {
+	for _, event := range abi.Events {
+		if event.Id() == topic {
+			return event, nil
+		}
+	}
+	return Event{}, fmt.Errorf("no event with id: %#x", topic)
+}
+
+// UnpackLog decodes a log of the named event into the struct out points to.
+// Every input, indexed or not, is assigned to the field tagged `abi:"<name>"`
+// or named after it (e.g. `from` and `_value` go to From and Value), see
+// Event.ParseLog for the representation of indexed inputs.
+func (abi ABI) UnpackLog(out interface{}, event string, log types.Log) error {
+	e, ok := abi.Events[event]
+	if !ok {
+		return fmt.Errorf("abi: could not locate named event %s", event)
+	}
+	valueOf := reflect.ValueOf(out)
+	if valueOf.Kind() != reflect.Ptr || valueOf.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("abi: UnpackLog(non-pointer to struct %T)", out)
+	}
+	values, err := e.unpackLog(log)
+	if err != nil {
+		return err
+	}
+	value := valueOf.Elem()
+	fields, err := structFieldMap(e.Inputs, value.Type())
+	if err != nil {
+		return err
+	}
+	for i, j := range fields {
+		if err := set(value.Field(j), reflect.ValueOf(values[i]), e.Inputs[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnpackLogIntoMap decodes a log of the named event into out, keyed by input
+// name. See Event.ParseLog.
+func (abi ABI) UnpackLogIntoMap(out map[string]interface{}, event string, log types.Log) error {
+	e, ok := abi.Events[event]
+	if !ok {
+		return fmt.Errorf("abi: could not locate named event %s", event)
+	}
+	values, err := e.ParseLog(log)
+	if err != nil {
+		return err
+	}
+	for name, value := range values {
+		out[name] = value
+	}
+	return nil
+}
+
+// abiField is the JSON representation of an abi entry.
+type abiField struct {
+	Type            string     `json:"type"`
+	Name            string     `json:"name,omitempty"`
+	Constant        bool       `json:"constant,omitempty"`
+	Payable         bool       `json:"payable,omitempty"`
+	StateMutability string     `json:"stateMutability,omitempty"`
+	Anonymous       bool       `json:"anonymous,omitempty"`
+	Inputs          []Argument `json:"inputs"`
+	Outputs         []Argument `json:"outputs,omitempty"`
+}
+
+// MarshalJSON encodes the abi in the solc JSON format, so that JSON(Marshal(abi))
+// gives back the same abi. Overloaded methods are written in the order of
+// their keys, so they get the same keys when read back.
+func (abi ABI) MarshalJSON() ([]byte, error) {
+	fields := []abiField{}
+
+	methodField := func(typ string, method Method) abiField {
+		field := abiField{
+			Type:            typ,
+			Name:            method.rawName(),
+			Constant:        method.IsConstant(),
+			Payable:         method.IsPayable(),
+			StateMutability: method.StateMutability,
+			Inputs:          nonNilArguments(method.Inputs),
+			Outputs:         method.Outputs,
+		}
+		if typ == "function" {
+			field.Outputs = nonNilArguments(field.Outputs)
+		}
+		return field
+	}
+	if abi.Constructor.Inputs != nil || abi.Constructor.StateMutability != "" {
+		constructor := methodField("constructor", abi.Constructor)
+		constructor.Name = ""
+		fields = append(fields, constructor)
+	}
+
+	keys := make([]string, 0, len(abi.Methods))
+	for key := range abi.Methods {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := abi.Methods[keys[i]], abi.Methods[keys[j]]
+		if a.rawName() != b.rawName() {
+			return a.rawName() < b.rawName()
+		}
+		// the overload keys are the raw name followed by an index
+		if len(a.Name) != len(b.Name) {
+			return len(a.Name) < len(b.Name)
+		}
+		return a.Name < b.Name
+	})
+	for _, key := range keys {
+		fields = append(fields, methodField("function", abi.Methods[key]))
+	}
+	if abi.Fallback.Name != "" {
+		fallback := methodField("fallback", abi.Fallback)
+		fallback.Name = ""
+		fields = append(fields, fallback)
+	}
+	if abi.Receive.Name != "" {
+		receive := methodField("receive", abi.Receive)
+		receive.Name = ""
+		fields = append(fields, receive)
+	}
+
+	names := make([]string, 0, len(abi.Events))
+	for name := range abi.Events {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		event := abi.Events[name]
+		fields = append(fields, abiField{Type: "event", Name: event.Name, Anonymous: event.Anonymous, Inputs: nonNilArguments(event.Inputs)})
+	}
+
+	names = names[:0]
+	for name := range abi.Errors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		e := abi.Errors[name]
+		fields = append(fields, abiField{Type: "error", Name: e.Name, Inputs: nonNilArguments(e.Inputs)})
+	}
+	return json.Marshal(fields)
+}
+
+// nonNilArguments makes empty argument lists encode as `[]` instead of `null`.
+func nonNilArguments(args []Argument) []Argument {
+	if args == nil {
+		return []Argument{}
+	}
+	return args
+}
+
 func (abi *ABI) UnmarshalJSON(data []byte) error {
 	var fields []struct {
-		Type      string
-		Name      string
-		Constant  bool
-		Indexed   bool
-		Anonymous bool
-		Inputs    []Argument
-		Outputs   []Argument
+		Type            string
+		Name            string
+		Constant        bool
+		Payable         bool
+		StateMutability string
+		Indexed         bool
+		Anonymous       bool
+		Inputs          []Argument
+		Outputs         []Argument
 	}
 
 	if err := json.Unmarshal(data, &fields); err != nil {
@@ -112,19 +344,57 @@ func (abi *ABI) UnmarshalJSON(data []byte) error {
 
 	abi.Methods = make(map[string]Method)
 	abi.Events = make(map[string]Event)
+	abi.Errors = make(map[string]Error)
 	for _, field := range fields {
+		mutability := stateMutability(field.StateMutability, field.Constant, field.Payable)
+		constant := mutability == "view" || mutability == "pure"
+		payable := mutability == "payable"
+
 		switch field.Type {
 		case "constructor":
 			abi.Constructor = Method{
-				Inputs: field.Inputs,
+				Inputs:          field.Inputs,
+				StateMutability: mutability,
+				Payable:         payable,
 			}
 		// empty defaults to function according to the abi spec
 		case "function", "":
-			abi.Methods[field.Name] = Method{
-				Name:    field.Name,
-				Const:   field.Constant,
-				Inputs:  field.Inputs,
-				Outputs: field.Outputs,
+			// overloaded methods are stored under unique keys: the first
+			// one keeps its name, the next ones get an index appended
+			name := field.Name
+			for idx := 0; ; idx++ {
+				if _, ok := abi.Methods[name]; !ok {
+					break
+				}
+				name = fmt.Sprintf("%s%d", field.Name, idx)
+			}
+			abi.Methods[name] = Method{
+				Name:            name,
+				RawName:         field.Name,
+				Const:           constant,
+				Inputs:          field.Inputs,
+				Outputs:         field.Outputs,
+				StateMutability: mutability,
+				Payable:         payable,
+			}
+		case "fallback":
+			abi.Fallback = Method{
+				Name:            "fallback",
+				RawName:         "fallback",
+				StateMutability: mutability,
+				Payable:         payable,
+			}
+		case "receive":
+			abi.Receive = Method{
+				Name:            "receive",
+				RawName:         "receive",
+				StateMutability: "payable",
+				Payable:         true,
+			}
+		case "error":
+			abi.Errors[field.Name] = Error{
+				Name:   field.Name,
+				Inputs: field.Inputs,
 			}
 		case "event":
 			abi.Events[field.Name] = Event{
@@ -137,3 +407,18 @@ func (abi *ABI) UnmarshalJSON(data []byte) error {
 
 	return nil
 }
+
+// stateMutability resolves the state mutability of an abi entry. Newer
+// compilers emit `stateMutability` directly, older ones only the `constant`
+// and `payable` flags.
+func stateMutability(mutability string, constant, payable bool) string {
+	switch {
+	case mutability != "":
+		return mutability
+	case constant:
+		return "view"
+	case payable:
+		return "payable"
+	}
+	return "nonpayable"
+}
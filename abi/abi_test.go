@@ -18,6 +18,7 @@ package abi
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"log"
 	"math/big"
@@ -55,15 +56,15 @@ const jsondata2 = `
 
 func TestReader(t *testing.T) {
 	Uint256, _ := NewType("uint256")
-	exp := CustomABI{
+	exp := ABI{
 		Methods: map[string]Method{
 			"balance": {
-				"balance", true, nil, nil,
+				Name: "balance", RawName: "balance", Const: true, StateMutability: "view",
 			},
 			"send": {
-				"send", false, []Argument{
+				Name: "send", RawName: "send", Inputs: []Argument{
 					{"amount", Uint256, false},
-				}, nil,
+				}, StateMutability: "nonpayable",
 			},
 		},
 	}
@@ -161,7 +162,7 @@ func TestTestSlice(t *testing.T) {
 
 func TestMethodSignature(t *testing.T) {
 	String, _ := NewType("string")
-	m := Method{"foo", false, []Argument{{"bar", String, false}, {"baz", String, false}}, nil}
+	m := Method{Name: "foo", Inputs: []Argument{{"bar", String, false}, {"baz", String, false}}}
 	exp := "foo(string,string)"
 	if m.Sig() != exp {
 		t.Error("signature mismatch", exp, "!=", m.Sig())
@@ -173,7 +174,7 @@ func TestMethodSignature(t *testing.T) {
 	}
 
 	uintt, _ := NewType("uint256")
-	m = Method{"foo", false, []Argument{{"bar", uintt, false}}, nil}
+	m = Method{Name: "foo", Inputs: []Argument{{"bar", uintt, false}}}
 	exp = "foo(uint256)"
 	if m.Sig() != exp {
 		t.Error("signature mismatch", exp, "!=", m.Sig())
@@ -361,6 +362,117 @@ func TestDefaultFunctionParsing(t *testing.T) {
 	}
 }
 
+func TestStateMutabilityParsing(t *testing.T) {
+	const definition = `[
+	{ "type" : "constructor", "payable" : true, "inputs" : [] },
+	{ "type" : "function", "name" : "legacyConst", "constant" : true },
+	{ "type" : "function", "name" : "legacyPayable", "payable" : true },
+	{ "type" : "function", "name" : "legacy" },
+	{ "type" : "function", "name" : "view", "stateMutability" : "view" },
+	{ "type" : "function", "name" : "pure", "stateMutability" : "pure" },
+	{ "type" : "function", "name" : "payable", "stateMutability" : "payable" },
+	{ "type" : "function", "name" : "nonpayable", "stateMutability" : "nonpayable" },
+	{ "type" : "fallback", "stateMutability" : "payable" },
+	{ "type" : "receive", "stateMutability" : "payable" }
+]`
+	abi, err := JSON(strings.NewReader(definition))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, exp := range map[string]struct {
+		mutability        string
+		constant, payable bool
+	}{
+		"legacyConst":   {"view", true, false},
+		"legacyPayable": {"payable", false, true},
+		"legacy":        {"nonpayable", false, false},
+		"view":          {"view", true, false},
+		"pure":          {"pure", true, false},
+		"payable":       {"payable", false, true},
+		"nonpayable":    {"nonpayable", false, false},
+	} {
+		m := abi.Methods[name]
+		if m.StateMutability != exp.mutability {
+			t.Errorf("%s: state mutability mismatch: have %s, want %s", name, m.StateMutability, exp.mutability)
+		}
+		if m.IsConstant() != exp.constant {
+			t.Errorf("%s: constant mismatch: have %v, want %v", name, m.IsConstant(), exp.constant)
+		}
+		if m.IsPayable() != exp.payable {
+			t.Errorf("%s: payable mismatch: have %v, want %v", name, m.IsPayable(), exp.payable)
+		}
+	}
+	if !abi.Constructor.IsPayable() {
+		t.Error("expected payable constructor")
+	}
+	if abi.Fallback.Name == "" || !abi.Fallback.IsPayable() {
+		t.Error("expected payable fallback")
+	}
+	if abi.Receive.Name == "" || !abi.Receive.IsPayable() {
+		t.Error("expected payable receive")
+	}
+}
+
+func TestOverloadedMethods(t *testing.T) {
+	const definition = `[
+	{ "type" : "function", "name" : "transfer", "inputs" : [ { "name" : "to", "type" : "address" }, { "name" : "value", "type" : "uint256" } ] },
+	{ "type" : "function", "name" : "transfer", "inputs" : [ { "name" : "to", "type" : "address" }, { "name" : "value", "type" : "uint256" }, { "name" : "data", "type" : "bytes" } ] },
+	{ "type" : "function", "name" : "transfer", "inputs" : [ { "name" : "value", "type" : "uint256" } ] }
+]`
+	abi, err := JSON(strings.NewReader(definition))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(abi.Methods) != 3 {
+		t.Fatalf("expected 3 methods, got %d", len(abi.Methods))
+	}
+	for key, sig := range map[string]string{
+		"transfer":  "transfer(address,uint256)",
+		"transfer0": "transfer(address,uint256,bytes)",
+		"transfer1": "transfer(uint256)",
+	} {
+		method, ok := abi.Methods[key]
+		if !ok {
+			t.Errorf("method %s not found", key)
+			continue
+		}
+		if method.Name != key || method.RawName != "transfer" {
+			t.Errorf("%s: name mismatch: have %s (%s)", key, method.Name, method.RawName)
+		}
+		if method.Sig() != sig {
+			t.Errorf("%s: signature mismatch: have %s, want %s", key, method.Sig(), sig)
+		}
+		bySig, err := abi.MethodBySignature(sig)
+		if err != nil || bySig.Name != key {
+			t.Errorf("%s: lookup by signature failed: %v", key, err)
+		}
+		byId, err := abi.MethodById(append(method.Id(), 1, 2, 3))
+		if err != nil || byId.Name != key {
+			t.Errorf("%s: lookup by id failed: %v", key, err)
+		}
+	}
+
+	// packing by key and by signature must produce the same overload
+	byKey, err := abi.Pack("transfer1", big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bySig, err := abi.Pack("transfer(uint256)", big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(byKey, bySig) {
+		t.Errorf("pack mismatch: %x != %x", byKey, bySig)
+	}
+	if _, err := abi.MethodById([]byte{1, 2, 3, 4}); err == nil {
+		t.Error("expected error for unknown method id")
+	}
+	if _, err := abi.MethodById([]byte{1}); err == nil {
+		t.Error("expected error for short call data")
+	}
+}
+
 func TestBareEvents(t *testing.T) {
 	const definition = `[
 	{ "type" : "event", "name" : "balance" },
@@ -418,3 +530,164 @@ func TestBareEvents(t *testing.T) {
 		}
 	}
 }
+
+func TestMarshalJSONRoundTrip(t *testing.T) {
+	const definition = `[
+	{ "type" : "constructor", "stateMutability" : "payable", "inputs" : [ { "name" : "owner", "type" : "address" } ] },
+	{ "type" : "function", "name" : "transfer", "inputs" : [ { "name" : "to", "type" : "address" }, { "name" : "value", "type" : "uint256" } ], "outputs" : [ { "name" : "", "type" : "bool" } ] },
+	{ "type" : "function", "name" : "transfer", "inputs" : [ { "name" : "to", "type" : "address" }, { "name" : "value", "type" : "uint256" }, { "name" : "data", "type" : "bytes" } ] },
+	{ "type" : "function", "name" : "balance", "constant" : true, "outputs" : [ { "name" : "", "type" : "uint256" } ] },
+	{ "type" : "function", "name" : "orders", "stateMutability" : "view", "inputs" : [ { "name" : "ids", "type" : "uint256[2][]" } ], "outputs" : [ { "name" : "", "type" : "tuple[]", "components" : [ { "name" : "id", "type" : "uint256" }, { "name" : "parts", "type" : "tuple[2]", "components" : [ { "name" : "owner", "type" : "address" } ] } ] } ] },
+	{ "type" : "fallback", "stateMutability" : "payable" },
+	{ "type" : "event", "name" : "Transfer", "inputs" : [ { "name" : "from", "type" : "address", "indexed" : true }, { "name" : "value", "type" : "uint256" } ] },
+	{ "type" : "event", "name" : "Anon", "anonymous" : true, "inputs" : [] },
+	{ "type" : "error", "name" : "Unauthorized", "inputs" : [ { "name" : "caller", "type" : "address" } ] }
+]`
+	abi, err := JSON(strings.NewReader(definition))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(abi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := JSON(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("failed to decode marshalled abi %s: %v", data, err)
+	}
+	for key, method := range abi.Methods {
+		if have := decoded.Methods[key]; have.String() != method.String() || have.Sig() != method.Sig() {
+			t.Errorf("method %s mismatch: have %v, want %v", key, have, method)
+		}
+	}
+	for name, event := range abi.Events {
+		if have := decoded.Events[name]; have.Id() != event.Id() || have.Anonymous != event.Anonymous || len(have.Inputs) != len(event.Inputs) {
+			t.Errorf("event %s mismatch: have %v, want %v", name, have, event)
+		}
+	}
+	if len(decoded.Methods) != len(abi.Methods) || len(decoded.Events) != len(abi.Events) || len(decoded.Errors) != len(abi.Errors) {
+		t.Errorf("entry count mismatch: %s", data)
+	}
+	if decoded.Constructor.String() != abi.Constructor.String() || decoded.Fallback.String() != abi.Fallback.String() {
+		t.Errorf("constructor or fallback mismatch: %s", data)
+	}
+	if !reflect.DeepEqual(decoded.Methods["orders"].Outputs[0].Type.Type, abi.Methods["orders"].Outputs[0].Type.Type) {
+		t.Errorf("tuple type mismatch: %v", decoded.Methods["orders"].Outputs[0].Type.Type)
+	}
+	again, err := json.Marshal(decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, again) {
+		t.Errorf("marshalling isn't stable:\n%s\n%s", data, again)
+	}
+}
+
+func TestHumanReadable(t *testing.T) {
+	const definition = `
+	// an ERC20 subset
+	function transfer(address to, uint amount) returns (bool)
+	function balanceOf(address) external view returns (uint256 balance)
+	allowance(address owner, address spender) view returns (uint256)
+	function submit((uint256 id, address[] owners)[] calldata orders, tuple(bytes32 a) single) payable
+	event Transfer(address indexed from, address indexed to, uint256 value)
+	event Log(string message) anonymous
+	error InsufficientBalance(uint256 available, uint256 required);
+	constructor(string memory name) payable
+	receive() external payable
+`
+	abi, err := HumanReadable(strings.NewReader(definition))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key, sig := range map[string]string{
+		"transfer":  "transfer(address,uint256)",
+		"balanceOf": "balanceOf(address)",
+		"allowance": "allowance(address,address)",
+		"submit":    "submit((uint256,address[])[],(bytes32))",
+	} {
+		if have := abi.Methods[key].Sig(); have != sig {
+			t.Errorf("%s: signature mismatch: have %s, want %s", key, have, sig)
+		}
+	}
+	if m := abi.Methods["transfer"]; m.IsConstant() || len(m.Outputs) != 1 || m.Outputs[0].Type.T != BoolTy {
+		t.Errorf("transfer parsed incorrectly: %v", m)
+	}
+	if m := abi.Methods["balanceOf"]; !m.IsConstant() || m.Outputs[0].Name != "balance" {
+		t.Errorf("balanceOf parsed incorrectly: %v", m)
+	}
+	if m := abi.Methods["submit"]; !m.IsPayable() || m.Inputs[0].Name != "orders" {
+		t.Errorf("submit parsed incorrectly: %v", m)
+	}
+	if id := abi.Events["Transfer"].Id(); id != crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)")) {
+		t.Errorf("event id mismatch: %x", id)
+	}
+	if e := abi.Events["Transfer"]; !e.Inputs[0].Indexed || !e.Inputs[1].Indexed || e.Inputs[2].Indexed {
+		t.Errorf("indexed inputs parsed incorrectly: %v", e.Inputs)
+	}
+	if !abi.Events["Log"].Anonymous {
+		t.Error("expected anonymous event")
+	}
+	if sig := abi.Errors["InsufficientBalance"].Sig(); sig != "InsufficientBalance(uint256,uint256)" {
+		t.Errorf("error signature mismatch: %s", sig)
+	}
+	if !abi.Constructor.IsPayable() || len(abi.Constructor.Inputs) != 1 {
+		t.Errorf("constructor parsed incorrectly: %v", abi.Constructor)
+	}
+	if !abi.Receive.IsPayable() {
+		t.Error("expected payable receive")
+	}
+
+	for _, invalid := range []string{
+		"function (uint256)",
+		"function foo(uint256",
+		"function foo(uint256 indexed a)",
+		"function foo(uint256 a b)",
+		"function foo() returns",
+		"function foo(uint256[)",
+		"function foo(unknown)",
+	} {
+		if _, err := HumanReadable(strings.NewReader(invalid)); err == nil {
+			t.Errorf("expected error for %q", invalid)
+		}
+	}
+}
+
+func TestUnpackInput(t *testing.T) {
+	const definition = `[
+	{ "type" : "function", "name" : "transfer", "inputs" : [ { "name" : "to", "type" : "address" }, { "name" : "", "type" : "uint256" }, { "name" : "memo", "type" : "string" } ] },
+	{ "type" : "function", "name" : "balance", "constant" : true }
+]`
+	abi, err := JSON(strings.NewReader(definition))
+	if err != nil {
+		t.Fatal(err)
+	}
+	to := common.HexToAddress("0x376c47978271565f56DEB45495afa69E59c16Ab2")
+	data, err := abi.Pack("transfer", to, big.NewInt(42), "hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	method, err := abi.MethodById(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if method.Name != "transfer" {
+		t.Fatalf("method mismatch: have %s, want transfer", method.Name)
+	}
+	args, err := abi.UnpackInput(method.Name, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := map[string]interface{}{"to": to, "arg1": big.NewInt(42), "memo": "hello"}
+	if !reflect.DeepEqual(args, exp) {
+		t.Errorf("arguments mismatch: have %v, want %v", args, exp)
+	}
+
+	if _, err := abi.UnpackInput("balance", data); err == nil {
+		t.Error("expected error for call data of another method")
+	}
+	if _, err := abi.UnpackInput("transfer", data[:40]); err == nil {
+		t.Error("expected error for truncated call data")
+	}
+}
@@ -29,18 +29,52 @@ type Argument struct {
 	Indexed bool // indexed is only used by events
 }
 
-func (a *Argument) UnmarshalJSON(data []byte) error {
-	var extarg struct {
-		Name    string
-		Type    string
-		Indexed bool
+// ArgumentMarshaling is the JSON representation of an argument. Tuple types
+// carry the description of their fields in Components.
+type ArgumentMarshaling struct {
+	Name       string               `json:"name"`
+	Type       string               `json:"type"`
+	Components []ArgumentMarshaling `json:"components,omitempty"`
+	Indexed    bool                 `json:"indexed,omitempty"`
+}
+
+// MarshalJSON encodes the argument the way solc does, describing tuple
+// types with their components.
+func (a Argument) MarshalJSON() ([]byte, error) {
+	return json.Marshal(newArgumentMarshaling(a.Name, a.Type, a.Indexed))
+}
+
+// newArgumentMarshaling builds the JSON representation of an argument of
+// type t. Tuples are written as `tuple` (plus their array suffixes) and
+// described by their components.
+func newArgumentMarshaling(name string, t Type, indexed bool) ArgumentMarshaling {
+	arg := ArgumentMarshaling{Name: name, Type: t.String(), Indexed: indexed}
+
+	var suffix string
+	for ; t.T == SliceTy || t.T == ArrayTy; t = *t.Elem {
+		if t.T == SliceTy {
+			suffix = "[]" + suffix
+		} else {
+			suffix = fmt.Sprintf("[%d]", t.Size) + suffix
+		}
 	}
+	if t.T == TupleTy {
+		arg.Type = "tuple" + suffix
+		for i, elem := range t.TupleElems {
+			arg.Components = append(arg.Components, newArgumentMarshaling(t.TupleRawNames[i], *elem, false))
+		}
+	}
+	return arg
+}
+
+func (a *Argument) UnmarshalJSON(data []byte) error {
+	var extarg ArgumentMarshaling
 	err := json.Unmarshal(data, &extarg)
 	if err != nil {
 		return fmt.Errorf("argument json err: %v", err)
 	}
 
-	a.Type, err = NewType(extarg.Type)
+	a.Type, err = NewType(extarg.Type, extarg.Components...)
 	if err != nil {
 		return err
 	}
@@ -49,3 +83,28 @@ func (a *Argument) UnmarshalJSON(data []byte) error {
 
 	return nil
 }
+
+// ArgumentName returns the name of the i-th argument, or `arg<index>` if it's
+// unnamed. Decoded values are keyed by it.
+func ArgumentName(args []Argument, i int) string {
+	if args[i].Name == "" {
+		return fmt.Sprintf("arg%d", i)
+	}
+	return args[i].Name
+}
+
+// unpackArguments decodes the values of args laid out as a tuple in output
+// into their go representations, in the order of args.
+func unpackArguments(args []Argument, output []byte) ([]interface{}, error) {
+	values := make([]interface{}, 0, len(args))
+	offset := 0
+	for _, arg := range args {
+		value, err := toGoType(offset, arg.Type, output)
+		if err != nil {
+			return nil, err
+		}
+		offset += getTypeSize(arg.Type)
+		values = append(values, value)
+	}
+	return values, nil
+}
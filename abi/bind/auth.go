@@ -21,6 +21,7 @@ import (
 	"errors"
 	"io"
 	"io/ioutil"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
@@ -42,8 +43,21 @@ func NewTransactor(keyin io.Reader, passphrase string) (*TransactOpts, error) {
 	return NewKeyedTransactor(key.PrivateKey), nil
 }
 
+// NewTransactorWithChainID is a utility method to easily create a transaction
+// signer from an encrypted json key stream and the associated passphrase, which
+// replay protects the transactions for the given chain.
+func NewTransactorWithChainID(keyin io.Reader, passphrase string, chainID *big.Int) (*TransactOpts, error) {
+	opts, err := NewTransactor(keyin, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	opts.ChainID = chainID
+	return opts, nil
+}
+
 // NewKeyedTransactor is a utility method to easily create a transaction signer
-// from a single private key.
+// from a single private key. The transactions are not replay protected, see
+// NewKeyedTransactorWithChainID.
 func NewKeyedTransactor(key *ecdsa.PrivateKey) *TransactOpts {
 	keyAddr := crypto.PubkeyToAddress(key.PublicKey)
 	return &TransactOpts{
@@ -60,3 +74,21 @@ func NewKeyedTransactor(key *ecdsa.PrivateKey) *TransactOpts {
 		},
 	}
 }
+
+// NewKeyedTransactorWithChainID is a utility method to easily create a
+// transaction signer from a single private key, which replay protects the
+// transactions for the given chain.
+func NewKeyedTransactorWithChainID(key *ecdsa.PrivateKey, chainID *big.Int) *TransactOpts {
+	opts := NewKeyedTransactor(key)
+	opts.ChainID = chainID
+	return opts
+}
+
+// ChainSigner returns the signer transactions are signed with for a chain: the
+// EIP-155 signer, or the homestead signer if the chain ID is not known.
+func ChainSigner(chainID *big.Int) types.Signer {
+	if chainID == nil {
+		return types.HomesteadSigner{}
+	}
+	return types.NewEIP155Signer(chainID)
+}
@@ -0,0 +1,61 @@
+// Copyright 2016 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bind_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"ethereum-front/abi"
+	"ethereum-front/abi/bind"
+	"ethereum-front/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Tests that transactions signed for a chain are replay protected and accepted
+// by a simulated blockchain of that chain.
+func TestChainIDTransactor(t *testing.T) {
+	from := crypto.PubkeyToAddress(testKey.PublicKey)
+	backend := backends.NewSimulatedBackendWithChainID(core.GenesisAlloc{
+		from: {Balance: big.NewInt(10000000000)},
+	}, big.NewInt(42))
+	if backend.ChainID().Int64() != 42 {
+		t.Fatalf("chain id mismatch: have %v, want 42", backend.ChainID())
+	}
+
+	auth := bind.NewKeyedTransactorWithChainID(testKey, backend.ChainID())
+	auth.GasLimit = big.NewInt(3000000)
+	_, tx, _, err := bind.DeployContract(auth, abi.ABI{}, common.FromHex(`6060604052600a8060106000396000f360606040526008565b00`), backend)
+	if err != nil {
+		t.Fatalf("failed to deploy: %v", err)
+	}
+	if !tx.Protected() || tx.ChainId().Int64() != 42 {
+		t.Fatalf("transaction not protected for chain 42: chain id %v", tx.ChainId())
+	}
+	if sender, err := types.Sender(types.NewEIP155Signer(big.NewInt(42)), tx); err != nil || sender != from {
+		t.Fatalf("sender mismatch: have %x (%v), want %x", sender, err, from)
+	}
+	backend.Commit()
+
+	if receipt, err := backend.TransactionReceipt(context.Background(), tx.Hash()); err != nil || receipt == nil {
+		t.Fatalf("transaction not mined: %v", err)
+	}
+}
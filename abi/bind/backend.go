@@ -21,6 +21,7 @@ import (
 	"errors"
 	"math/big"
 
+	"ethereum-front/abi"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -36,11 +37,39 @@ var (
 	// on a backend that doesn't implement PendingContractCaller.
 	ErrNoPendingState = errors.New("backend does not support pending state")
 
+	// This error is returned by FilterLogs and WatchLogs if the contract was
+	// bound without a ContractFilterer.
+	ErrNoFilterer = errors.New("contract is bound without a log filterer")
+
 	// This error is returned by WaitDeployed if contract creation leaves an
 	// empty contract behind.
 	ErrNoCodeAfterDeploy = errors.New("no contract code after deployment")
 )
 
+// RevertError is returned by call and transact operations the EVM reverted.
+// Data holds the raw revert data returned by the contract and Reason its
+// human readable form, empty if the data couldn't be decoded.
+type RevertError struct {
+	Reason string
+	Data   []byte
+}
+
+// NewRevertError creates a revert error out of the data returned by a
+// reverted call, decoding the standard Error(string) and Panic(uint256)
+// reasons. Contract specific errors are decoded by BoundContract, which
+// knows the contract abi.
+func NewRevertError(data []byte) *RevertError {
+	reason, _ := abi.UnpackRevert(data)
+	return &RevertError{Reason: reason, Data: common.CopyBytes(data)}
+}
+
+func (e *RevertError) Error() string {
+	if e.Reason == "" {
+		return "execution reverted"
+	}
+	return "execution reverted: " + e.Reason
+}
+
 // ContractCaller defines the methods needed to allow operating with contract on a read
 // only basis.
 type ContractCaller interface {
@@ -90,8 +119,20 @@ type ContractTransactor interface {
 	SendTransaction(ctx context.Context, tx *types.Transaction) error
 }
 
+// ContractFilterer defines the methods needed to access log events using one-off
+// queries or continuous event subscriptions.
+type ContractFilterer interface {
+	// FilterLogs executes a log filter operation, blocking during execution and
+	// returning all the results in one batch.
+	FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error)
+	// SubscribeFilterLogs creates a background log filtering operation, returning
+	// a subscription immediately, which can be used to stream the found events.
+	SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error)
+}
+
 // ContractBackend defines the methods needed to work with contracts on a read-write basis.
 type ContractBackend interface {
 	ContractCaller
 	ContractTransactor
+	ContractFilterer
 }
@@ -24,8 +24,8 @@ import (
 	"sync"
 	"time"
 
+	"ethereum-front/abi/bind"
 	"github.com/ethereum/go-ethereum"
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
@@ -34,13 +34,14 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/params"
 )
 
 // This nil assignment ensures compile time that SimulatedBackend implements bind.ContractBackend.
 var _ bind.ContractBackend = (*SimulatedBackend)(nil)
 
-var errBlockNumberUnsupported = errors.New("SimulatedBackend cannot access blocks other than the latest block")
+var errBlockDoesNotExist = errors.New("block does not exist in blockchain")
 var errGasEstimationFailed = errors.New("gas required exceeds allowance or always failing transaction")
 
 // SimulatedBackend implements bind.ContractBackend, simulating a blockchain in
@@ -53,14 +54,26 @@ type SimulatedBackend struct {
 	pendingBlock *types.Block   // Currently pending block that will be imported on request
 	pendingState *state.StateDB // Currently pending state that will be the active on on request
 
+	logsFeed event.Feed // Feed of the logs of every committed block, for log subscriptions
+
 	config *params.ChainConfig
 }
 
 // NewSimulatedBackend creates a new binding backend using a simulated blockchain
 // for testing purposes.
 func NewSimulatedBackend(alloc core.GenesisAlloc) *SimulatedBackend {
+	return NewSimulatedBackendWithChainID(alloc, nil)
+}
+
+// NewSimulatedBackendWithChainID creates a new binding backend using a simulated
+// blockchain with the given chain ID (nil = the default of the test chain).
+func NewSimulatedBackendWithChainID(alloc core.GenesisAlloc, chainID *big.Int) *SimulatedBackend {
+	config := *params.AllEthashProtocolChanges
+	if chainID != nil {
+		config.ChainId = new(big.Int).Set(chainID)
+	}
 	database, _ := ethdb.NewMemDatabase()
-	genesis := core.Genesis{Config: params.AllEthashProtocolChanges, Alloc: alloc}
+	genesis := core.Genesis{Config: &config, Alloc: alloc}
 	genesis.MustCommit(database)
 	blockchain, _ := core.NewBlockChain(database, genesis.Config, ethash.NewFaker(), vm.Config{})
 	backend := &SimulatedBackend{database: database, blockchain: blockchain, config: genesis.Config}
@@ -68,16 +81,28 @@ func NewSimulatedBackend(alloc core.GenesisAlloc) *SimulatedBackend {
 	return backend
 }
 
+// ChainID returns the chain ID of the simulated blockchain, which transactions
+// are replay protected for.
+func (b *SimulatedBackend) ChainID() *big.Int {
+	return new(big.Int).Set(b.config.ChainId)
+}
+
 // Commit imports all the pending transactions as a single block and starts a
 // fresh new state.
 func (b *SimulatedBackend) Commit() {
 	b.mu.Lock()
-	defer b.mu.Unlock()
-
 	if _, err := b.blockchain.InsertChain([]*types.Block{b.pendingBlock}); err != nil {
+		b.mu.Unlock()
 		panic(err) // This cannot happen unless the simulator is wrong, fail in that case
 	}
+	logs := b.blockLogs(b.pendingBlock)
 	b.rollback()
+	b.mu.Unlock()
+
+	// Deliver the logs without holding the lock, as slow subscribers block the send
+	if len(logs) > 0 {
+		b.logsFeed.Send(logs)
+	}
 }
 
 // Rollback aborts all pending transactions, reverting to the last committed state.
@@ -99,10 +124,10 @@ func (b *SimulatedBackend) CodeAt(ctx context.Context, contract common.Address,
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
-	if blockNumber != nil && blockNumber.Cmp(b.blockchain.CurrentBlock().Number()) != 0 {
-		return nil, errBlockNumberUnsupported
+	_, statedb, err := b.stateByNumber(blockNumber)
+	if err != nil {
+		return nil, err
 	}
-	statedb, _ := b.blockchain.State()
 	return statedb.GetCode(contract), nil
 }
 
@@ -111,10 +136,10 @@ func (b *SimulatedBackend) BalanceAt(ctx context.Context, contract common.Addres
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
-	if blockNumber != nil && blockNumber.Cmp(b.blockchain.CurrentBlock().Number()) != 0 {
-		return nil, errBlockNumberUnsupported
+	_, statedb, err := b.stateByNumber(blockNumber)
+	if err != nil {
+		return nil, err
 	}
-	statedb, _ := b.blockchain.State()
 	return statedb.GetBalance(contract), nil
 }
 
@@ -123,10 +148,10 @@ func (b *SimulatedBackend) NonceAt(ctx context.Context, contract common.Address,
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
-	if blockNumber != nil && blockNumber.Cmp(b.blockchain.CurrentBlock().Number()) != 0 {
-		return 0, errBlockNumberUnsupported
+	_, statedb, err := b.stateByNumber(blockNumber)
+	if err != nil {
+		return 0, err
 	}
-	statedb, _ := b.blockchain.State()
 	return statedb.GetNonce(contract), nil
 }
 
@@ -135,14 +160,33 @@ func (b *SimulatedBackend) StorageAt(ctx context.Context, contract common.Addres
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
-	if blockNumber != nil && blockNumber.Cmp(b.blockchain.CurrentBlock().Number()) != 0 {
-		return nil, errBlockNumberUnsupported
+	_, statedb, err := b.stateByNumber(blockNumber)
+	if err != nil {
+		return nil, err
 	}
-	statedb, _ := b.blockchain.State()
 	val := statedb.GetState(contract, key)
 	return val[:], nil
 }
 
+// stateByNumber returns a block of the canonical chain along with its state,
+// the latest block if blockNumber is nil. The simulated chain keeps the state
+// of every block, so historical state is always available.
+func (b *SimulatedBackend) stateByNumber(blockNumber *big.Int) (*types.Block, *state.StateDB, error) {
+	if blockNumber == nil || blockNumber.Cmp(b.blockchain.CurrentBlock().Number()) == 0 {
+		statedb, err := b.blockchain.State()
+		return b.blockchain.CurrentBlock(), statedb, err
+	}
+	if blockNumber.Sign() < 0 || blockNumber.Cmp(b.blockchain.CurrentBlock().Number()) > 0 {
+		return nil, nil, errBlockDoesNotExist
+	}
+	block := b.blockchain.GetBlockByNumber(blockNumber.Uint64())
+	if block == nil {
+		return nil, nil, errBlockDoesNotExist
+	}
+	statedb, err := b.blockchain.StateAt(block.Root())
+	return block, statedb, err
+}
+
 // TransactionReceipt returns the receipt of a transaction.
 func (b *SimulatedBackend) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
 	receipt, _, _, _ := core.GetReceipt(b.database, txHash)
@@ -157,19 +201,20 @@ func (b *SimulatedBackend) PendingCodeAt(ctx context.Context, contract common.Ad
 	return b.pendingState.GetCode(contract), nil
 }
 
-// CallContract executes a contract call.
+// CallContract executes a contract call. Calls reverted by the EVM return a
+// *bind.RevertError carrying the revert data.
 func (b *SimulatedBackend) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
-	if blockNumber != nil && blockNumber.Cmp(b.blockchain.CurrentBlock().Number()) != 0 {
-		return nil, errBlockNumberUnsupported
-	}
-	state, err := b.blockchain.State()
+	block, state, err := b.stateByNumber(blockNumber)
 	if err != nil {
 		return nil, err
 	}
-	rval, _, _, err := b.callContract(ctx, call, b.blockchain.CurrentBlock(), state)
+	rval, _, failed, err := b.callContract(ctx, call, block, state)
+	if err == nil && failed {
+		return nil, bind.NewRevertError(rval)
+	}
 	return rval, err
 }
 
@@ -179,7 +224,10 @@ func (b *SimulatedBackend) PendingCallContract(ctx context.Context, call ethereu
 	defer b.mu.Unlock()
 	defer b.pendingState.RevertToSnapshot(b.pendingState.Snapshot())
 
-	rval, _, _, err := b.callContract(ctx, call, b.pendingBlock, b.pendingState)
+	rval, _, failed, err := b.callContract(ctx, call, b.pendingBlock, b.pendingState)
+	if err == nil && failed {
+		return nil, bind.NewRevertError(rval)
+	}
 	return rval, err
 }
 
@@ -217,15 +265,18 @@ func (b *SimulatedBackend) EstimateGas(ctx context.Context, call ethereum.CallMs
 	}
 	cap = hi
 
-	// Create a helper to check if a gas allowance results in an executable transaction
+	// Create a helper to check if a gas allowance results in an executable transaction,
+	// keeping the data returned by the last failed attempt to report reverts
+	var revert []byte
 	executable := func(gas uint64) bool {
 		call.Gas = new(big.Int).SetUint64(gas)
 
 		snapshot := b.pendingState.Snapshot()
-		_, _, failed, err := b.callContract(ctx, call, b.pendingBlock, b.pendingState)
+		rval, _, failed, err := b.callContract(ctx, call, b.pendingBlock, b.pendingState)
 		b.pendingState.RevertToSnapshot(snapshot)
 
 		if err != nil || failed {
+			revert = rval
 			return false
 		}
 		return true
@@ -242,6 +293,11 @@ func (b *SimulatedBackend) EstimateGas(ctx context.Context, call ethereum.CallMs
 	// Reject the transaction as invalid if it still fails at the highest allowance
 	if hi == cap {
 		if !executable(hi) {
+			// The transaction fails even with all the gas, report the
+			// reason if the contract gave one
+			if len(revert) > 0 {
+				return nil, bind.NewRevertError(revert)
+			}
 			return nil, errGasEstimationFailed
 		}
 	}
@@ -282,7 +338,7 @@ func (b *SimulatedBackend) SendTransaction(ctx context.Context, tx *types.Transa
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
-	sender, err := types.Sender(types.HomesteadSigner{}, tx)
+	sender, err := types.Sender(types.MakeSigner(b.config, b.pendingBlock.Number()), tx)
 	if err != nil {
 		panic(fmt.Errorf("invalid transaction: %v", err))
 	}
@@ -302,6 +358,110 @@ func (b *SimulatedBackend) SendTransaction(ctx context.Context, tx *types.Transa
 	return nil
 }
 
+// FilterLogs executes a log filter operation on the canonical chain, returning
+// all the logs matching the query in one batch. A nil FromBlock or ToBlock
+// stands for the latest block.
+func (b *SimulatedBackend) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	from := b.blockchain.CurrentBlock().NumberU64()
+	to := from
+	if query.FromBlock != nil {
+		from = query.FromBlock.Uint64()
+	}
+	if query.ToBlock != nil && query.ToBlock.Uint64() < to {
+		to = query.ToBlock.Uint64()
+	}
+	var logs []types.Log
+	for number := from; number <= to; number++ {
+		block := b.blockchain.GetBlockByNumber(number)
+		if block == nil {
+			break
+		}
+		logs = append(logs, filterLogs(b.blockLogs(block), query)...)
+	}
+	return logs, nil
+}
+
+// SubscribeFilterLogs creates a background log filtering operation, streaming
+// the logs matching the query out of every block committed from now on.
+func (b *SimulatedBackend) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	sink := make(chan []*types.Log)
+	sub := b.logsFeed.Subscribe(sink)
+
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case logs := <-sink:
+				for _, log := range filterLogs(logs, query) {
+					select {
+					case ch <- log:
+					case err := <-sub.Err():
+						return err
+					case <-quit:
+						return nil
+					}
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// blockLogs returns the logs of a block of the canonical chain.
+func (b *SimulatedBackend) blockLogs(block *types.Block) []*types.Log {
+	var logs []*types.Log
+	for _, receipt := range core.GetBlockReceipts(b.database, block.Hash(), block.NumberU64()) {
+		for _, log := range receipt.Logs {
+			log.BlockNumber, log.BlockHash = block.NumberU64(), block.Hash()
+			logs = append(logs, log)
+		}
+	}
+	return logs
+}
+
+// filterLogs returns the logs matching the addresses and topics of the query.
+func filterLogs(logs []*types.Log, query ethereum.FilterQuery) []types.Log {
+	var matched []types.Log
+Logs:
+	for _, log := range logs {
+		if len(query.Addresses) > 0 && !includes(query.Addresses, log.Address) {
+			continue
+		}
+		if len(query.Topics) > len(log.Topics) {
+			continue
+		}
+		for i, topics := range query.Topics {
+			match := len(topics) == 0 // empty rule set == wildcard
+			for _, topic := range topics {
+				if log.Topics[i] == topic {
+					match = true
+					break
+				}
+			}
+			if !match {
+				continue Logs
+			}
+		}
+		matched = append(matched, *log)
+	}
+	return matched
+}
+
+func includes(addresses []common.Address, a common.Address) bool {
+	for _, addr := range addresses {
+		if addr == a {
+			return true
+		}
+	}
+	return false
+}
+
 // JumpTimeInSeconds adds skip seconds to the clock
 func (b *SimulatedBackend) AdjustTime(adjustment time.Duration) error {
 	b.mu.Lock()
@@ -0,0 +1,124 @@
+// Copyright 2015 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package backends_test
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"ethereum-front/abi"
+	"ethereum-front/abi/bind"
+	"ethereum-front/abi/bind/backends"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// pingABI and pingBin describe a contract raising a Ping() event whenever it
+// is called: the deploy code returns the runtime code
+// PUSH32 keccak256("Ping()") PUSH1 0 PUSH1 0 LOG1 STOP.
+const (
+	pingABI = `[{"anonymous":false,"inputs":[],"name":"Ping","type":"event"}]`
+	pingBin = `6027600c60003960276000f3` +
+		`7fca6e822df923f741dfe968d15d80a18abd25bd1e748bcb9ad81fea5bbb7386af60006000a100`
+)
+
+// Tests that logs of committed blocks are streamed to the watchers, and that a
+// subscriber not reading its logs blocks neither the commits of others nor any
+// other backend call.
+func TestWatchLogsCommit(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	auth := bind.NewKeyedTransactor(key)
+	auth.GasLimit = big.NewInt(100000)
+	sim := backends.NewSimulatedBackend(core.GenesisAlloc{auth.From: {Balance: big.NewInt(10000000000)}})
+
+	parsed, err := abi.JSON(strings.NewReader(pingABI))
+	if err != nil {
+		t.Fatalf("failed to parse abi: %v", err)
+	}
+	address, _, contract, err := bind.DeployContract(auth, parsed, common.FromHex(pingBin), sim)
+	if err != nil {
+		t.Fatalf("failed to deploy contract: %v", err)
+	}
+	sim.Commit()
+
+	stalled := make(chan types.Log, 1)
+	sub, err := sim.SubscribeFilterLogs(context.Background(), ethereum.FilterQuery{Addresses: []common.Address{address}}, stalled)
+	if err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	it, err := contract.WatchLogs(nil, "Ping")
+	if err != nil {
+		t.Fatalf("failed to watch logs: %v", err)
+	}
+	defer it.Close()
+
+	// Ping the contract in three blocks, the last commit blocking on the stalled subscriber
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 3; i++ {
+			if _, err := contract.Transfer(auth); err != nil {
+				t.Errorf("failed to ping contract: %v", err)
+				return
+			}
+			sim.Commit()
+		}
+	}()
+	for i := 0; i < 3; i++ {
+		if !it.Next() {
+			t.Fatalf("log %d: watch ended: %v", i, it.Error())
+		}
+		if it.Log.Address != address || it.Log.Topics[0] != parsed.Events["Ping"].Id() {
+			t.Fatalf("log %d: unexpected log %+v", i, it.Log)
+		}
+	}
+
+	// The backend must stay responsive while the commit waits on the subscriber
+	filtered := make(chan int)
+	go func() {
+		logs, err := sim.FilterLogs(context.Background(), ethereum.FilterQuery{FromBlock: big.NewInt(0), Addresses: []common.Address{address}})
+		if err != nil {
+			t.Errorf("failed to filter logs: %v", err)
+		}
+		filtered <- len(logs)
+	}()
+	select {
+	case n := <-filtered:
+		if n != 3 {
+			t.Errorf("filtered log count mismatch: have %d, want 3", n)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("filtering logs blocked on a stalled subscriber")
+	}
+
+	// Dropping the stalled subscriber releases the blocked commit
+	sub.Unsubscribe()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("commit blocked after the stalled subscriber left")
+	}
+	if len(stalled) != 1 {
+		t.Errorf("stalled subscriber log count mismatch: have %d, want 1", len(stalled))
+	}
+}
@@ -36,8 +36,9 @@ type SignerFn func(types.Signer, common.Address, *types.Transaction) (*types.Tra
 
 // CallOpts is the collection of options to fine tune a contract call request.
 type CallOpts struct {
-	Pending bool           // Whether to operate on the pending state or the last known one
-	From    common.Address // Optional the sender address, otherwise the first account is used
+	Pending     bool           // Whether to operate on the pending state or the last known one
+	From        common.Address // Optional the sender address, otherwise the first account is used
+	BlockNumber *big.Int       // Optional the block number to call at, otherwise the latest block (ignored if Pending)
 
 	Context context.Context // Network context to support cancellation and timeouts (nil = no timeout)
 }
@@ -45,9 +46,10 @@ type CallOpts struct {
 // TransactOpts is the collection of authorization data required to create a
 // valid Ethereum transaction.
 type TransactOpts struct {
-	From   common.Address // Ethereum account to send the transaction from
-	Nonce  *big.Int       // Nonce to use for the transaction execution (nil = use pending state)
-	Signer SignerFn       // Method to use for signing the transaction (mandatory)
+	From    common.Address // Ethereum account to send the transaction from
+	Nonce   *big.Int       // Nonce to use for the transaction execution (nil = use pending state)
+	Signer  SignerFn       // Method to use for signing the transaction (mandatory)
+	ChainID *big.Int       // Chain ID to replay protect the transaction for (nil = no EIP-155 protection)
 
 	Value    *big.Int // Funds to transfer along along the transaction (nil = 0 = no funds)
 	GasPrice *big.Int // Gas price to use for the transaction execution (nil = gas price oracle)
@@ -56,6 +58,22 @@ type TransactOpts struct {
 	Context context.Context // Network context to support cancellation and timeouts (nil = no timeout)
 }
 
+// FilterOpts is the collection of options to fine tune filtering for events
+// within a bound contract.
+type FilterOpts struct {
+	Start uint64  // Start of the queried range
+	End   *uint64 // End of the range (nil = latest)
+
+	Context context.Context // Network context to support cancellation and timeouts (nil = no timeout)
+}
+
+// WatchOpts is the collection of options to fine tune subscribing for events
+// within a bound contract.
+type WatchOpts struct {
+	Start   *uint64         // Start of the queried range (nil = latest)
+	Context context.Context // Network context to support cancellation and timeouts (nil = no timeout)
+}
+
 // BoundContract is the base wrapper object that reflects a contract on the
 // Ethereum network. It contains a collection of methods that are used by the
 // higher level contract bindings to operate.
@@ -64,16 +82,18 @@ type BoundContract struct {
 	abi        abi.ABI            // Reflect based ABI to access the correct Ethereum methods
 	caller     ContractCaller     // Read interface to interact with the blockchain
 	transactor ContractTransactor // Write interface to interact with the blockchain
+	filterer   ContractFilterer   // Event filtering to interact with the blockchain
 }
 
-// NewBoundContract creates a low level contract interface through which calls
-// and transactions may be made through.
-func NewBoundContract(address common.Address, abi abi.ABI, caller ContractCaller, transactor ContractTransactor) *BoundContract {
+// NewBoundContract creates a low level contract interface through which calls,
+// transactions and event queries may be made through.
+func NewBoundContract(address common.Address, abi abi.ABI, caller ContractCaller, transactor ContractTransactor, filterer ContractFilterer) *BoundContract {
 	return &BoundContract{
 		address:    address,
 		abi:        abi,
 		caller:     caller,
 		transactor: transactor,
+		filterer:   filterer,
 	}
 }
 
@@ -81,7 +101,7 @@ func NewBoundContract(address common.Address, abi abi.ABI, caller ContractCaller
 // deployment address with a Go wrapper.
 func DeployContract(opts *TransactOpts, abi abi.ABI, bytecode []byte, backend ContractBackend, params ...interface{}) (common.Address, *types.Transaction, *BoundContract, error) {
 	// Otherwise try to deploy the contract
-	c := NewBoundContract(common.Address{}, abi, backend, backend)
+	c := NewBoundContract(common.Address{}, abi, backend, backend, backend)
 
 	input, err := c.abi.Pack("", params...)
 	if err != nil {
@@ -98,7 +118,8 @@ func DeployContract(opts *TransactOpts, abi abi.ABI, bytecode []byte, backend Co
 // Call invokes the (constant) contract method with params as input values and
 // sets the output to result. The result type might be a single field for simple
 // returns, a slice of interfaces for anonymous returns and a struct for named
-// returns.
+// returns. Overloaded methods are addressed by their key in abi.Methods or by
+// their signature.
 func (c *BoundContract) Call(opts *CallOpts, result interface{}, method string, params ...interface{}) error {
 	// Don't crash on a lazy user
 	if opts == nil {
@@ -130,16 +151,19 @@ func (c *BoundContract) Call(opts *CallOpts, result interface{}, method string,
 			}
 		}
 	} else {
-		output, err = c.caller.CallContract(ctx, msg, nil)
+		output, err = c.caller.CallContract(ctx, msg, opts.BlockNumber)
 		if err == nil && len(output) == 0 {
 			// Make sure we have a contract to operate on, and bail out otherwise.
-			if code, err = c.caller.CodeAt(ctx, c.address, nil); err != nil {
+			if code, err = c.caller.CodeAt(ctx, c.address, opts.BlockNumber); err != nil {
 				return err
 			} else if len(code) == 0 {
 				return ErrNoCode
 			}
 		}
 	}
+	if revert := c.revertError(output, err); revert != nil {
+		return revert
+	}
 	if err != nil {
 		return err
 	}
@@ -147,6 +171,7 @@ func (c *BoundContract) Call(opts *CallOpts, result interface{}, method string,
 }
 
 // Transact invokes the (paid) contract method with params as input values.
+// The method is addressed the same way as in Call.
 func (c *BoundContract) Transact(opts *TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
 	// Otherwise pack up the parameters and invoke the contract
 	input, err := c.abi.Pack(method, params...)
@@ -203,6 +228,13 @@ func (c *BoundContract) transact(opts *TransactOpts, contract *common.Address, i
 		msg := ethereum.CallMsg{From: opts.From, To: contract, Value: value, Data: input}
 		gasLimit, err = c.transactor.EstimateGas(ensureContext(opts.Context), msg)
 		if err != nil {
+			// Report why the transaction would fail if it simply reverts
+			if revert := c.revertError(nil, err); revert != nil {
+				return nil, revert
+			}
+			if revert := c.callRevert(ensureContext(opts.Context), msg); revert != nil {
+				return nil, revert
+			}
 			return nil, fmt.Errorf("failed to estimate gas needed: %v", err)
 		}
 	}
@@ -216,7 +248,7 @@ func (c *BoundContract) transact(opts *TransactOpts, contract *common.Address, i
 	if opts.Signer == nil {
 		return nil, errors.New("no signer to authorize the transaction with")
 	}
-	signedTx, err := opts.Signer(types.HomesteadSigner{}, opts.From, rawTx)
+	signedTx, err := opts.Signer(ChainSigner(opts.ChainID), opts.From, rawTx)
 	if err != nil {
 		return nil, err
 	}
@@ -226,6 +258,135 @@ func (c *BoundContract) transact(opts *TransactOpts, contract *common.Address, i
 	return signedTx, nil
 }
 
+// RevertReason replays a mined transaction of the contract that failed as a
+// call against the current state, returning the revert error explaining the
+// failure or nil if the call doesn't revert. The replay can't reproduce the
+// exact state the transaction was executed on, so the result is only a best
+// effort diagnosis.
+func (c *BoundContract) RevertReason(opts *CallOpts, tx *types.Transaction) *RevertError {
+	// Don't crash on a lazy user
+	if opts == nil {
+		opts = new(CallOpts)
+	}
+	msg := ethereum.CallMsg{From: opts.From, To: tx.To(), Value: tx.Value(), Data: tx.Data()}
+	return c.callRevert(ensureContext(opts.Context), msg)
+}
+
+// callRevert executes msg as a call on the pending state if the backend
+// supports it, returning the revert error if the EVM reverts it.
+func (c *BoundContract) callRevert(ctx context.Context, msg ethereum.CallMsg) *RevertError {
+	var (
+		output []byte
+		err    error
+	)
+	if pb, ok := c.caller.(PendingContractCaller); ok {
+		output, err = pb.PendingCallContract(ctx, msg)
+	} else {
+		output, err = c.caller.CallContract(ctx, msg, nil)
+	}
+	return c.revertError(output, err)
+}
+
+// revertError detects a reverted call out of its results and decodes the
+// revert reason, recognising the custom errors of the contract. Backends
+// report reverts with a *RevertError, while older nodes return the revert
+// data as the call output. Such output is told apart from regular return
+// values, which are multiples of 32 bytes, by the leading 4-byte selector.
+func (c *BoundContract) revertError(output []byte, err error) *RevertError {
+	if revert, ok := err.(*RevertError); ok {
+		if reason, err := c.abi.RevertReason(revert.Data); err == nil {
+			return &RevertError{Reason: reason, Data: revert.Data}
+		}
+		return revert
+	}
+	if err == nil && len(output)%32 == 4 {
+		if reason, err := c.abi.RevertReason(output); err == nil {
+			return &RevertError{Reason: reason, Data: output}
+		}
+	}
+	return nil
+}
+
+// FilterLogs filters the logs of the named contract event in past blocks,
+// returning an iterator over the decoded events. The query lists the values
+// accepted for each indexed input of the event, see abi.Event.Topics.
+func (c *BoundContract) FilterLogs(opts *FilterOpts, name string, query ...[]interface{}) (*LogIterator, error) {
+	// Don't crash on a lazy user
+	if opts == nil {
+		opts = new(FilterOpts)
+	}
+	config, err := c.filterQuery(name, query)
+	if err != nil {
+		return nil, err
+	}
+	config.FromBlock = new(big.Int).SetUint64(opts.Start)
+	if opts.End != nil {
+		config.ToBlock = new(big.Int).SetUint64(*opts.End)
+	}
+	logs, err := c.filterer.FilterLogs(ensureContext(opts.Context), config)
+	if err != nil {
+		return nil, err
+	}
+	// the filtered logs are streamed over a closed channel
+	ch := make(chan types.Log, len(logs))
+	for _, log := range logs {
+		ch <- log
+	}
+	close(ch)
+	return &LogIterator{contract: c, event: name, logs: ch}, nil
+}
+
+// WatchLogs subscribes to the logs of the named contract event in new blocks,
+// returning an iterator over the decoded events which blocks until the next
+// event arrives, the subscription fails or the iterator is closed.
+func (c *BoundContract) WatchLogs(opts *WatchOpts, name string, query ...[]interface{}) (*LogIterator, error) {
+	// Don't crash on a lazy user
+	if opts == nil {
+		opts = new(WatchOpts)
+	}
+	config, err := c.filterQuery(name, query)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Start != nil {
+		config.FromBlock = new(big.Int).SetUint64(*opts.Start)
+	}
+	ch := make(chan types.Log, 128)
+	sub, err := c.filterer.SubscribeFilterLogs(ensureContext(opts.Context), config, ch)
+	if err != nil {
+		return nil, err
+	}
+	return &LogIterator{contract: c, event: name, logs: ch, sub: sub}, nil
+}
+
+// UnpackLog unpacks a retrieved log of the named event into the provided
+// output structure.
+func (c *BoundContract) UnpackLog(out interface{}, event string, log types.Log) error {
+	return c.abi.UnpackLog(out, event, log)
+}
+
+// UnpackLogIntoMap unpacks a retrieved log of the named event into the provided
+// map, keyed by input name.
+func (c *BoundContract) UnpackLogIntoMap(out map[string]interface{}, event string, log types.Log) error {
+	return c.abi.UnpackLogIntoMap(out, event, log)
+}
+
+// filterQuery builds the log query of the named event of the contract.
+func (c *BoundContract) filterQuery(name string, query [][]interface{}) (ethereum.FilterQuery, error) {
+	if c.filterer == nil {
+		return ethereum.FilterQuery{}, ErrNoFilterer
+	}
+	event, ok := c.abi.Events[name]
+	if !ok {
+		return ethereum.FilterQuery{}, fmt.Errorf("event '%s' not found", name)
+	}
+	topics, err := event.Topics(query...)
+	if err != nil {
+		return ethereum.FilterQuery{}, err
+	}
+	return ethereum.FilterQuery{Addresses: []common.Address{c.address}, Topics: topics}, nil
+}
+
 func ensureContext(ctx context.Context) context.Context {
 	if ctx == nil {
 		return context.TODO()
@@ -24,11 +24,12 @@ import (
 	"bytes"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 	"text/template"
 	"unicode"
 
-	"github.com/ethereum/go-ethereum/accounts/abi"
+	"ethereum-front/abi"
 	"golang.org/x/tools/imports"
 )
 
@@ -39,6 +40,7 @@ const (
 	LangGo Lang = iota
 	LangJava
 	LangObjC
+	LangTypeScript
 )
 
 // Bind generates a Go wrapper around a contract ABI. This wrapper isn't meant
@@ -47,7 +49,10 @@ const (
 // manually maintain hard coded strings that break on runtime.
 func Bind(types []string, abis []string, bytecodes []string, pkg string, lang Lang) (string, error) {
 	// Process each individual contract requested binding
-	contracts := make(map[string]*tmplContract)
+	var (
+		contracts = make(map[string]*tmplContract)
+		structs   = make(map[string]*tmplStruct)
+	)
 
 	for i := 0; i < len(types); i++ {
 		// Parse the actual ABI to generate the binding for
@@ -55,6 +60,10 @@ func Bind(types []string, abis []string, bytecodes []string, pkg string, lang La
 		if err != nil {
 			return "", err
 		}
+		// Bind the argument types ahead, as the structs of the tuples come first
+		if err := bindArguments(evmABI, lang, structs); err != nil {
+			return "", fmt.Errorf("%s: %v", types[i], err)
+		}
 		// Strip any whitespace from the JSON ABI
 		strippedABI := strings.Map(func(r rune) rune {
 			if unicode.IsSpace(r) {
@@ -73,13 +82,7 @@ func Bind(types []string, abis []string, bytecodes []string, pkg string, lang La
 			normalized := original
 			normalized.Name = methodNormalizer[lang](original.Name)
 
-			normalized.Inputs = make([]abi.Argument, len(original.Inputs))
-			copy(normalized.Inputs, original.Inputs)
-			for j, input := range normalized.Inputs {
-				if input.Name == "" {
-					normalized.Inputs[j].Name = fmt.Sprintf("arg%d", j)
-				}
-			}
+			normalized.Inputs = normalizeArguments(original.Inputs, lang)
 			normalized.Outputs = make([]abi.Argument, len(original.Outputs))
 			copy(normalized.Outputs, original.Outputs)
 			for j, output := range normalized.Outputs {
@@ -88,33 +91,55 @@ func Bind(types []string, abis []string, bytecodes []string, pkg string, lang La
 				}
 			}
 			// Append the methods to the call or transact lists
-			if original.Const {
+			if original.IsConstant() {
 				calls[original.Name] = &tmplMethod{Original: original, Normalized: normalized, Structured: structured(original)}
 			} else {
 				transacts[original.Name] = &tmplMethod{Original: original, Normalized: normalized, Structured: structured(original)}
 			}
 		}
+		// Extract the events, normalizing their names and inputs the same way
+		events := make(map[string]*tmplEvent)
+		for _, original := range evmABI.Events {
+			normalized := original
+			normalized.Name = methodNormalizer[lang](original.Name)
+
+			normalized.Inputs = normalizeArguments(original.Inputs, lang)
+			events[original.Name] = &tmplEvent{Original: original, Normalized: normalized}
+		}
+		// Name the constructor inputs the same way, as they become deploy parameters
+		constructor := evmABI.Constructor
+		constructor.Inputs = normalizeArguments(evmABI.Constructor.Inputs, lang)
+
 		contracts[types[i]] = &tmplContract{
 			Type:        capitalise(types[i]),
 			InputABI:    strings.Replace(strippedABI, "\"", "\\\"", -1),
 			InputBin:    strings.TrimSpace(bytecodes[i]),
-			Constructor: evmABI.Constructor,
+			Constructor: constructor,
 			Calls:       calls,
 			Transacts:   transacts,
+			Events:      events,
 		}
 	}
 	// Generate the contract template data content and render it
 	data := &tmplData{
 		Package:   pkg,
 		Contracts: contracts,
+		Structs:   make([]*tmplStruct, len(structs)),
+	}
+	for _, s := range structs {
+		data.Structs[s.index] = s
 	}
 	buffer := new(bytes.Buffer)
 
 	funcs := map[string]interface{}{
-		"bindtype":     bindType[lang],
-		"namedtype":    namedType[lang],
-		"capitalise":   capitalise,
-		"decapitalise": decapitalise,
+		"bindtype":      func(kind abi.Type) string { return bindType[lang](kind, structs) },
+		"bindtopictype": func(kind abi.Type) string { return bindTopicType[lang](kind, structs) },
+		"hastuple":      hasTuple,
+		"namedtype":     namedType[lang],
+		"capitalise":    capitalise,
+		"decapitalise":  decapitalise,
+		"camelcase":     abi.ToCamelCase,
+		"argname":       abi.ArgumentName,
 	}
 	tmpl := template.Must(template.New("").Funcs(funcs).Parse(tmplSource[lang]))
 	if err := tmpl.Execute(buffer, data); err != nil {
@@ -132,17 +157,102 @@ func Bind(types []string, abis []string, bytecodes []string, pkg string, lang La
 	return string(buffer.Bytes()), nil
 }
 
+// bindArguments binds the types of all the arguments of a contract, declaring
+// the structs of its tuples in order of appearance. It fails on the types the
+// target language has no binding for.
+func bindArguments(evmABI abi.ABI, lang Lang, structs map[string]*tmplStruct) error {
+	var methods, events []string
+	for name := range evmABI.Methods {
+		methods = append(methods, name)
+	}
+	for name := range evmABI.Events {
+		events = append(events, name)
+	}
+	sort.Strings(methods)
+	sort.Strings(events)
+
+	bind := func(owner string, args []abi.Argument) error {
+		for i, arg := range args {
+			// The geth mobile library has no tuple, fixed point nor function values
+			if lang == LangJava && !javaSupported(arg.Type) {
+				return fmt.Errorf("Java bindings don't support %s %s of %s", arg.Type, abi.ArgumentName(args, i), owner)
+			}
+			bindType[lang](arg.Type, structs)
+		}
+		return nil
+	}
+	if err := bind("constructor", evmABI.Constructor.Inputs); err != nil {
+		return err
+	}
+	for _, name := range methods {
+		if err := bind(name, evmABI.Methods[name].Inputs); err != nil {
+			return err
+		}
+		if err := bind(name, evmABI.Methods[name].Outputs); err != nil {
+			return err
+		}
+	}
+	for _, name := range events {
+		// indexed inputs are bound to their topic, which is a hash for tuples
+		var inputs []abi.Argument
+		for _, arg := range evmABI.Events[name].Inputs {
+			if !arg.Indexed {
+				inputs = append(inputs, arg)
+			}
+		}
+		if err := bind(name, inputs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// javaSupported reports whether a Solidity type has a Java binding.
+func javaSupported(kind abi.Type) bool {
+	switch kind.T {
+	case abi.TupleTy, abi.FixedPointTy, abi.FunctionTy:
+		return false
+	case abi.SliceTy, abi.ArrayTy:
+		return javaSupported(*kind.Elem)
+	}
+	return true
+}
+
+// hasTuple reports whether a Solidity type is or holds a tuple.
+func hasTuple(kind abi.Type) bool {
+	switch kind.T {
+	case abi.TupleTy:
+		return true
+	case abi.SliceTy, abi.ArrayTy:
+		return hasTuple(*kind.Elem)
+	}
+	return false
+}
+
 // bindType is a set of type binders that convert Solidity types to some supported
-// programming language.
-var bindType = map[Lang]func(kind abi.Type) string{
-	LangGo:   bindTypeGo,
-	LangJava: bindTypeJava,
+// programming language. Tuples bound to named types are declared in structs.
+var bindType = map[Lang]func(kind abi.Type, structs map[string]*tmplStruct) string{
+	LangGo:         bindTypeGo,
+	LangJava:       bindTypeJava,
+	LangTypeScript: bindTypeTypeScript,
 }
 
 // bindTypeGo converts a Solidity type to a Go one. Since there is no clear mapping
 // from all Solidity types to Go ones (e.g. uint17), those that cannot be exactly
-// mapped will use an upscaled type (e.g. *big.Int).
-func bindTypeGo(kind abi.Type) string {
+// mapped will use an upscaled type (e.g. *big.Int). Tuples map to named structs.
+func bindTypeGo(kind abi.Type, structs map[string]*tmplStruct) string {
+	switch kind.T {
+	case abi.TupleTy:
+		return bindStructTypeGo(kind, structs)
+	case abi.SliceTy:
+		return "[]" + bindTypeGo(*kind.Elem, structs)
+	case abi.ArrayTy:
+		return fmt.Sprintf("[%d]", kind.Size) + bindTypeGo(*kind.Elem, structs)
+	case abi.FixedPointTy:
+		return "*abi.Fixed"
+	case abi.FunctionTy:
+		return "[24]byte"
+	}
 	stringKind := kind.String()
 
 	switch {
@@ -186,7 +296,7 @@ func bindTypeGo(kind abi.Type) string {
 // bindTypeJava converts a Solidity type to a Java one. Since there is no clear mapping
 // from all Solidity types to Java ones (e.g. uint17), those that cannot be exactly
 // mapped will use an upscaled type (e.g. BigDecimal).
-func bindTypeJava(kind abi.Type) string {
+func bindTypeJava(kind abi.Type, structs map[string]*tmplStruct) string {
 	stringKind := kind.String()
 
 	switch {
@@ -254,11 +364,109 @@ func bindTypeJava(kind abi.Type) string {
 	}
 }
 
+// bindStructTypeGo returns the name of the struct binding a tuple, declaring
+// it (after the structs of its nested tuples) in structs when first seen. The
+// fields are named like the ones of the struct the abi package unpacks into.
+func bindStructTypeGo(kind abi.Type, structs map[string]*tmplStruct) string {
+	key := kind.Type.String()
+	if s, ok := structs[key]; ok {
+		return s.Name
+	}
+	fields := make([]*tmplField, len(kind.TupleElems))
+	for i, elem := range kind.TupleElems {
+		name := abi.ToCamelCase(kind.TupleRawNames[i])
+		if name == "" {
+			name = fmt.Sprintf("Field%d", i)
+		}
+		fields[i] = &tmplField{Name: name, Type: bindTypeGo(*elem, structs), Tag: kind.TupleRawNames[i]}
+	}
+	name := fmt.Sprintf("Struct%d", len(structs))
+	structs[key] = &tmplStruct{Name: name, Fields: fields, index: len(structs)}
+	return name
+}
+
+// bindTypeTypeScript converts a Solidity type to a TypeScript one. All integers
+// map to bigint regardless of their size, addresses and byte strings to hex
+// string literal types and tuples to object types keyed by their field names,
+// Field<index> for the unnamed ones.
+func bindTypeTypeScript(kind abi.Type, structs map[string]*tmplStruct) string {
+	switch kind.T {
+	case abi.SliceTy, abi.ArrayTy:
+		return bindTypeTypeScript(*kind.Elem, structs) + "[]"
+
+	case abi.TupleTy:
+		fields := make([]string, len(kind.TupleElems))
+		for i, elem := range kind.TupleElems {
+			name := kind.TupleRawNames[i]
+			if name == "" {
+				name = fmt.Sprintf("Field%d", i)
+			}
+			fields[i] = fmt.Sprintf("%s: %s", name, bindTypeTypeScript(*elem, structs))
+		}
+		return "{ " + strings.Join(fields, "; ") + " }"
+
+	case abi.IntTy, abi.UintTy, abi.FixedPointTy:
+		return "bigint"
+
+	case abi.AddressTy, abi.FixedBytesTy, abi.BytesTy, abi.HashTy, abi.FunctionTy:
+		return "`0x${string}`"
+
+	case abi.BoolTy:
+		return "boolean"
+
+	case abi.StringTy:
+		return "string"
+
+	default:
+		return "unknown"
+	}
+}
+
+// bindTopicType is a set of type binders that convert the Solidity types of
+// indexed event inputs to some supported programming language. Only value
+// types are logged as is, all other values are logged as their hash.
+var bindTopicType = map[Lang]func(kind abi.Type, structs map[string]*tmplStruct) string{
+	LangGo:         bindTopicTypeGo,
+	LangJava:       bindTopicTypeJava,
+	LangTypeScript: bindTopicTypeTypeScript,
+}
+
+// bindTopicTypeGo converts the Solidity type of an indexed event input to a Go
+// one, which is common.Hash for the hashed types.
+func bindTopicTypeGo(kind abi.Type, structs map[string]*tmplStruct) string {
+	switch kind.T {
+	case abi.StringTy, abi.BytesTy, abi.SliceTy, abi.ArrayTy, abi.TupleTy:
+		return "common.Hash"
+	}
+	return bindTypeGo(kind, structs)
+}
+
+// bindTopicTypeJava converts the Solidity type of an indexed event input to a
+// Java one, which is Hash for the hashed types.
+func bindTopicTypeJava(kind abi.Type, structs map[string]*tmplStruct) string {
+	switch kind.T {
+	case abi.StringTy, abi.BytesTy, abi.SliceTy, abi.ArrayTy, abi.TupleTy:
+		return "Hash"
+	}
+	return bindTypeJava(kind, structs)
+}
+
+// bindTopicTypeTypeScript converts the Solidity type of an indexed event input
+// to a TypeScript one, which is a hex string for the hashed types.
+func bindTopicTypeTypeScript(kind abi.Type, structs map[string]*tmplStruct) string {
+	switch kind.T {
+	case abi.StringTy, abi.BytesTy, abi.SliceTy, abi.ArrayTy, abi.TupleTy:
+		return "`0x${string}`"
+	}
+	return bindTypeTypeScript(kind, structs)
+}
+
 // namedType is a set of functions that transform language specific types to
 // named versions that my be used inside method names.
 var namedType = map[Lang]func(string, abi.Type) string{
-	LangGo:   func(string, abi.Type) string { panic("this shouldn't be needed") },
-	LangJava: namedTypeJava,
+	LangGo:         func(string, abi.Type) string { panic("this shouldn't be needed") },
+	LangJava:       namedTypeJava,
+	LangTypeScript: func(string, abi.Type) string { panic("this shouldn't be needed") },
 }
 
 // namedTypeJava converts some primitive data types to named variants that can
@@ -300,8 +508,50 @@ func namedTypeJava(javaKind string, solKind abi.Type) string {
 // methodNormalizer is a name transformer that modifies Solidity method names to
 // conform to target language naming concentions.
 var methodNormalizer = map[Lang]func(string) string{
-	LangGo:   capitalise,
-	LangJava: decapitalise,
+	LangGo:         capitalise,
+	LangJava:       decapitalise,
+	LangTypeScript: decapitalise,
+}
+
+// normalizeArguments copies the arguments, naming them so they are usable as
+// parameters in the target language: unnamed arguments and the ones named
+// after a reserved word are named by their position, e.g. arg0.
+func normalizeArguments(args []abi.Argument, lang Lang) []abi.Argument {
+	normalized := make([]abi.Argument, len(args))
+	copy(normalized, args)
+	for j := range normalized {
+		normalized[j].Name = abi.ArgumentName(args, j)
+		if reservedWords[lang][normalized[j].Name] {
+			normalized[j].Name = fmt.Sprintf("arg%d", j)
+		}
+	}
+	return normalized
+}
+
+// reservedWords is a set of the words of the target languages that can't be
+// used as parameter names: keywords and the parameters the bindings declare.
+var reservedWords = map[Lang]map[string]bool{
+	LangGo: wordSet(`break case chan const continue default defer else fallthrough for func go goto if
+		import interface map package range return select struct switch type var`),
+	LangJava: wordSet(`abstract assert boolean break byte case catch char class const continue default do
+		double else enum extends final finally float for goto if implements import instanceof int
+		interface long native new package private protected public return short static strictfp super
+		switch synchronized this throw throws transient try void volatile while true false null
+		opts auth client args results`),
+	LangTypeScript: wordSet(`break case catch class const continue debugger default delete do else enum export
+		extends false finally for function if import in instanceof new null return super switch this
+		throw true try typeof var void while with implements interface let package private protected
+		public static yield await any boolean number string symbol
+		opts runner filter onEvent onError`),
+}
+
+// wordSet returns the set of the whitespace separated words.
+func wordSet(words string) map[string]bool {
+	set := make(map[string]bool)
+	for _, word := range strings.Fields(words) {
+		set[word] = true
+	}
+	return set
 }
 
 // capitalise makes the first character of a string upper case.
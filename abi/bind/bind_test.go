@@ -26,6 +26,7 @@ import (
 	"strings"
 	"testing"
 
+	"ethereum-front/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"golang.org/x/tools/imports"
 )
@@ -447,6 +448,59 @@ var bindTests = []struct {
 			}
 		`,
 	},
+	// Tests that events are bound with typed filterers and log parsers
+	{
+		`EventChecker`, ``, ``,
+		`
+			[
+				{"type":"event","name":"empty","inputs":[]},
+				{"type":"event","name":"indexed","inputs":[{"name":"addr","type":"address","indexed":true},{"name":"num","type":"int256","indexed":true}]},
+				{"type":"event","name":"mixed","inputs":[{"name":"addr","type":"address","indexed":true},{"name":"num","type":"int256"}]},
+				{"type":"event","name":"dynamic","inputs":[{"name":"idxStr","type":"string","indexed":true},{"name":"str","type":"string"}]},
+				{"type":"event","name":"arrays","inputs":[{"name":"idxArr","type":"uint256[]","indexed":true},{"name":"arr","type":"uint256[]"}]}
+			]
+		`,
+		`
+			if b, err := NewEventCheckerFilterer(common.Address{}, nil); b == nil || err != nil {
+				t.Fatalf("filterer binding (%v) nil or error (%v) not nil", b, nil)
+			}
+			checker, err := NewEventChecker(common.Address{}, nil)
+			if err != nil {
+				t.Fatalf("Failed to bind event checker: %v", err)
+			}
+			var (
+				_ func(*bind.FilterOpts) (*EventCheckerEmptyIterator, error) = checker.FilterEmpty
+				_ func(*bind.FilterOpts, []common.Address, []*big.Int) (*EventCheckerIndexedIterator, error) = checker.FilterIndexed
+				_ func(*bind.WatchOpts, chan<- *EventCheckerMixed, []common.Address) (event.Subscription, error) = checker.WatchMixed
+				_ func(*bind.FilterOpts, []common.Hash) (*EventCheckerDynamicIterator, error) = checker.FilterDynamic
+				_ func(*bind.WatchOpts, chan<- *EventCheckerArrays, []common.Hash) (event.Subscription, error) = checker.WatchArrays
+			)
+			addr := common.HexToAddress("0x0102030405060708090a0b0c0d0e0f1011121314")
+			mixed, err := checker.ParseMixed(types.Log{
+				Topics: []common.Hash{crypto.Keccak256Hash([]byte("mixed(address,int256)")), common.BytesToHash(addr.Bytes())},
+				Data:   common.LeftPadBytes(big.NewInt(42).Bytes(), 32),
+			})
+			if err != nil {
+				t.Fatalf("Failed to parse mixed event: %v", err)
+			}
+			if mixed.Addr != addr || mixed.Num.Cmp(big.NewInt(42)) != 0 {
+				t.Fatalf("Mixed event mismatch: have %v, %v, want %v, %v", mixed.Addr, mixed.Num, addr, 42)
+			}
+			if _, err := checker.ParseMixed(types.Log{Topics: []common.Hash{crypto.Keccak256Hash([]byte("empty()"))}}); err == nil {
+				t.Fatalf("Parsed a foreign event as mixed")
+			}
+			dynamic, err := checker.ParseDynamic(types.Log{
+				Topics: []common.Hash{crypto.Keccak256Hash([]byte("dynamic(string,string)")), crypto.Keccak256Hash([]byte("hello"))},
+				Data:   common.FromHex("0x000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000086869207468657265000000000000000000000000000000000000000000000000"),
+			})
+			if err != nil {
+				t.Fatalf("Failed to parse dynamic event: %v", err)
+			}
+			if dynamic.IdxStr != crypto.Keccak256Hash([]byte("hello")) || dynamic.Str != "hi there" {
+				t.Fatalf("Dynamic event mismatch: have %x, %q", dynamic.IdxStr, dynamic.Str)
+			}
+		`,
+	},
 }
 
 // Tests that packages generated by the binder can be successfully compiled and
@@ -463,7 +517,7 @@ func TestBindings(t *testing.T) {
 	if err != nil {
 		t.Fatalf("failed check for goimports symlink bug: %v", err)
 	}
-	if !strings.Contains(string(linkTestDeps), "go-ethereum") {
+	if !strings.Contains(string(linkTestDeps), "abi/bind/backends") {
 		t.Skip("symlinked environment doesn't support bind (https://github.com/golang/go/issues/14845)")
 	}
 	// Create a temporary workspace for the test suite
@@ -504,3 +558,102 @@ func TestBindings(t *testing.T) {
 		t.Fatalf("failed to run binding test: %v\n%s", err, out)
 	}
 }
+
+// Tests that Solidity types are mapped to their TypeScript counterparts.
+func TestBindTypeTypeScript(t *testing.T) {
+	tests := []struct {
+		kind string
+		want string
+	}{
+		{"uint8", "bigint"},
+		{"int256[]", "bigint[]"},
+		{"address", "`0x${string}`"},
+		{"address[2]", "`0x${string}`[]"},
+		{"bytes32", "`0x${string}`"},
+		{"bytes", "`0x${string}`"},
+		{"bool", "boolean"},
+		{"string[][]", "string[][]"},
+	}
+	for _, tt := range tests {
+		kind, err := abi.NewType(tt.kind)
+		if err != nil {
+			t.Fatalf("%s: failed to parse type: %v", tt.kind, err)
+		}
+		if have := bindTypeTypeScript(kind, nil); have != tt.want {
+			t.Errorf("%s: have %s, want %s", tt.kind, have, tt.want)
+		}
+	}
+	kind, _ := abi.NewType("string")
+	if have := bindTopicTypeTypeScript(kind, nil); have != "`0x${string}`" {
+		t.Errorf("indexed string: have %s, want hash", have)
+	}
+}
+
+// Tests that a full TypeScript binding names the unnamed and reserved word
+// arguments so that they are valid parameters.
+func TestBindTypeScript(t *testing.T) {
+	abiJSON := `[
+		{"type":"constructor","inputs":[{"name":"","type":"address"},{"name":"default","type":"uint256"}]},
+		{"type":"function","name":"balanceOf","constant":true,"inputs":[{"name":"","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+		{"type":"function","name":"transfer","constant":false,"inputs":[{"name":"to","type":"address"},{"name":"new","type":"uint256"}],"outputs":[]},
+		{"type":"function","name":"transfer","constant":false,"inputs":[{"name":"to","type":"address"}],"outputs":[]},
+		{"type":"function","name":"pair","constant":true,"inputs":[],"outputs":[{"name":"","type":"tuple","components":[{"name":"","type":"uint256"},{"name":"owner","type":"address"}]}]},
+		{"type":"event","name":"Transfer","inputs":[{"name":"from","type":"address","indexed":true},{"name":"delete","type":"string","indexed":true},{"name":"","type":"uint256","indexed":false}]}
+	]`
+	code, err := Bind([]string{"Token"}, []string{abiJSON}, []string{"0x6060"}, "", LangTypeScript)
+	if err != nil {
+		t.Fatalf("failed to generate binding: %v", err)
+	}
+	for _, want := range []string{
+		"static async deploy(runner: ContractRunner, arg0: `0x${string}`, arg1: bigint, opts?: TransactOptions)",
+		"runner.deploy(TokenABI, TokenBin, [arg0, arg1, ], opts)",
+		"async balanceOf(arg0: `0x${string}`, opts?: CallOptions): Promise<bigint>",
+		"async transfer(to: `0x${string}`, arg1: bigint, opts?: TransactOptions)",
+		"this.runner.transact(this.address, TokenABI, \"transfer(address,uint256)\", [to, arg1, ], opts)",
+		"this.runner.transact(this.address, TokenABI, \"transfer(address)\", [to, ], opts)",
+		"async pair(opts?: CallOptions): Promise<{ Field0: bigint; owner: `0x${string}` }>",
+		"async filterTransfer(from: `0x${string}`[] = [], arg1: `0x${string}`[] = [], filter?: LogFilter)",
+		"return { from: values[0] as `0x${string}`, arg1: values[1] as `0x${string}`, arg2: values[2] as bigint, raw: log };",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("binding misses %q:\n%s", want, code)
+		}
+	}
+	for _, invalid := range []string{"(: ", ", : ", "{ : ", "; : ", "default:", "new:", "delete:", "\"transfer0\"", "\"transfer1\""} {
+		if strings.Contains(code, invalid) {
+			t.Errorf("binding contains invalid %q:\n%s", invalid, code)
+		}
+	}
+}
+
+// Tests that tuples are bound to named Go structs, also when nested or unnamed,
+// and that the Java bindings reject them.
+func TestBindGoTuples(t *testing.T) {
+	abiJSON := `[
+		{"type":"function","name":"get","constant":true,"inputs":[],"outputs":[{"name":"","type":"tuple","components":[{"name":"amount","type":"uint256"},{"name":"owner","type":"address"}]}]},
+		{"type":"function","name":"pair","constant":true,"inputs":[],"outputs":[{"name":"","type":"tuple","components":[{"name":"","type":"uint256"},{"name":"","type":"bool"}]}]},
+		{"type":"function","name":"place","constant":false,"inputs":[{"name":"orders","type":"tuple[]","components":[{"name":"id","type":"uint256"},{"name":"inner","type":"tuple","components":[{"name":"price","type":"ufixed128x18"},{"name":"callback","type":"function"}]}]}],"outputs":[]},
+		{"type":"event","name":"Placed","inputs":[{"name":"who","type":"address","indexed":true},{"name":"order","type":"tuple","indexed":false,"components":[{"name":"amount","type":"uint256"},{"name":"owner","type":"address"}]}]}
+	]`
+	code, err := Bind([]string{"Orders"}, []string{abiJSON}, []string{"0x6060"}, "orders", LangGo)
+	if err != nil {
+		t.Fatalf("failed to generate binding: %v", err)
+	}
+	for _, want := range []string{
+		"type Struct0 struct {\n\tAmount *big.Int       `json:\"amount\"`\n\tOwner  common.Address `json:\"owner\"`\n}",
+		"type Struct1 struct {\n\tField0 *big.Int `json:\"\"`\n\tField1 bool     `json:\"\"`\n}",
+		"type Struct2 struct {\n\tPrice    *abi.Fixed `json:\"price\"`\n\tCallback [24]byte   `json:\"callback\"`\n}",
+		"type Struct3 struct {\n\tId    *big.Int `json:\"id\"`\n\tInner Struct2  `json:\"inner\"`\n}",
+		"func (_Orders *OrdersCaller) Get(opts *bind.CallOpts) (Struct0, error)",
+		"func (_Orders *OrdersCaller) Pair(opts *bind.CallOpts) (Struct1, error)",
+		"func (_Orders *OrdersTransactor) Place(opts *bind.TransactOpts, orders []Struct3) (*types.Transaction, error)",
+		"abi.Convert(&event.Order, values[\"order\"])",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("binding misses %q:\n%s", want, code)
+		}
+	}
+	if _, err := Bind([]string{"Orders"}, []string{abiJSON}, []string{"0x6060"}, "orders", LangJava); err == nil {
+		t.Error("expected the Java binding to reject tuples")
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2015 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bind
+
+import (
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// LogIterator is returned from FilterLogs and WatchLogs and is used to iterate
+// over the logs of a contract event along with their decoded inputs.
+type LogIterator struct {
+	Log    types.Log              // Log the iterator is positioned at
+	Values map[string]interface{} // Inputs of the event decoded out of the log, keyed by name
+
+	contract *BoundContract // Contract the event belongs to
+	event    string         // Name of the event
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination (nil when filtering)
+	done bool                  // Whether the subscription completed delivering logs
+	end  error                 // Error the subscription ended with, reported once the logs are drained
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *LogIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// Filtered logs are delivered over a closed channel
+	if it.sub == nil {
+		log, ok := <-it.logs
+		if !ok {
+			return false
+		}
+		return it.decode(log)
+	}
+	// If the subscription finished, deliver any remaining logs
+	if it.done {
+		select {
+		case log := <-it.logs:
+			return it.decode(log)
+		default:
+			it.fail = it.end
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		return it.decode(log)
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.end = err
+		return it.Next()
+	}
+}
+
+// decode positions the iterator at the log.
+func (it *LogIterator) decode(log types.Log) bool {
+	values := make(map[string]interface{})
+	if err := it.contract.UnpackLogIntoMap(values, it.event, log); err != nil {
+		it.fail = err
+		return false
+	}
+	it.Log, it.Values = log, values
+	return true
+}
+
+// Unpack decodes the current event into the struct out points to, see
+// abi.ABI.UnpackLog.
+func (it *LogIterator) Unpack(out interface{}) error {
+	return it.contract.UnpackLog(out, it.event, it.Log)
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *LogIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *LogIterator) Close() error {
+	if it.sub != nil {
+		it.sub.Unsubscribe()
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+// Copyright 2016 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bind_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"ethereum-front/abi"
+	"ethereum-front/abi/bind"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+const transferABI = `[{"type":"event","name":"Transfer","inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"value","type":"uint256"}]}]`
+
+// mockFilterer records the last query and returns the logs given. Subscriptions
+// stream the logs and then end with the error given.
+type mockFilterer struct {
+	query ethereum.FilterQuery
+	logs  []types.Log
+	end   error
+}
+
+func (f *mockFilterer) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	f.query = query
+	return f.logs, nil
+}
+
+func (f *mockFilterer) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	f.query = query
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		for _, log := range f.logs {
+			ch <- log
+		}
+		return f.end
+	}), nil
+}
+
+func TestFilterLogs(t *testing.T) {
+	parsed, err := abi.JSON(strings.NewReader(transferABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		contract = common.HexToAddress("0x0000000000000000000000000000000000000c0c")
+		from     = common.HexToAddress("0x00000000000000000000000000000000000000aa")
+		to       = common.HexToAddress("0x00000000000000000000000000000000000000bb")
+		id       = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	)
+	filterer := &mockFilterer{logs: []types.Log{{
+		Address: contract,
+		Topics:  []common.Hash{id, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())},
+		Data:    common.LeftPadBytes(big.NewInt(42).Bytes(), 32),
+	}}}
+	c := bind.NewBoundContract(contract, parsed, nil, nil, filterer)
+
+	end := uint64(10)
+	it, err := c.FilterLogs(&bind.FilterOpts{Start: 1, End: &end}, "Transfer", []interface{}{from})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := ethereum.FilterQuery{
+		FromBlock: big.NewInt(1),
+		ToBlock:   big.NewInt(10),
+		Addresses: []common.Address{contract},
+		Topics:    [][]common.Hash{{id}, {common.BytesToHash(from.Bytes())}},
+	}
+	if !reflect.DeepEqual(filterer.query, want) {
+		t.Errorf("expected query %+v, got %+v", want, filterer.query)
+	}
+
+	if !it.Next() {
+		t.Fatalf("expected an event, got error %v", it.Error())
+	}
+	if it.Values["from"] != from || it.Values["to"] != to || it.Values["value"].(*big.Int).Int64() != 42 {
+		t.Errorf("unexpected values %v", it.Values)
+	}
+	var event struct {
+		From  common.Address
+		To    common.Address
+		Value *big.Int
+	}
+	if err := it.Unpack(&event); err != nil {
+		t.Fatal(err)
+	}
+	if event.From != from || event.Value.Int64() != 42 {
+		t.Errorf("unexpected event %+v", event)
+	}
+	if it.Next() {
+		t.Error("expected the iteration to end")
+	}
+	if err := it.Error(); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := c.FilterLogs(nil, "Approval"); err == nil {
+		t.Error("expected an error filtering an unknown event")
+	}
+	if _, err := bind.NewBoundContract(contract, parsed, nil, nil, nil).FilterLogs(nil, "Transfer"); err != bind.ErrNoFilterer {
+		t.Errorf("expected ErrNoFilterer, got %v", err)
+	}
+}
+
+func TestWatchLogsDrain(t *testing.T) {
+	parsed, err := abi.JSON(strings.NewReader(transferABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		contract = common.HexToAddress("0x0000000000000000000000000000000000000c0c")
+		id       = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+		failure  = errors.New("connection lost")
+	)
+	filterer := &mockFilterer{end: failure}
+	for i := int64(1); i <= 3; i++ {
+		filterer.logs = append(filterer.logs, types.Log{
+			Address: contract,
+			Topics:  []common.Hash{id, {}, {}},
+			Data:    common.LeftPadBytes(big.NewInt(i).Bytes(), 32),
+		})
+	}
+	c := bind.NewBoundContract(contract, parsed, nil, nil, filterer)
+
+	it, err := c.WatchLogs(nil, "Transfer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer it.Close()
+
+	// The logs buffered before the subscription failed are delivered first
+	for i := int64(1); i <= 3; i++ {
+		if !it.Next() {
+			t.Fatalf("log %d: expected an event, got error %v", i, it.Error())
+		}
+		if value := it.Values["value"].(*big.Int); value.Int64() != i {
+			t.Errorf("log %d: unexpected value %v", i, value)
+		}
+	}
+	if it.Next() {
+		t.Error("expected the iteration to end")
+	}
+	if err := it.Error(); err != failure {
+		t.Errorf("expected error %v, got %v", failure, err)
+	}
+}
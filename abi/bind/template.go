@@ -16,12 +16,13 @@
 
 package bind
 
-import "github.com/ethereum/go-ethereum/accounts/abi"
+import "ethereum-front/abi"
 
 // tmplData is the data structure required to fill the binding template.
 type tmplData struct {
 	Package   string                   // Name of the package to place the generated file in
 	Contracts map[string]*tmplContract // List of contracts to generate into this file
+	Structs   []*tmplStruct            // Structs binding the tuples of the contracts
 }
 
 // tmplContract contains the data needed to generate an individual contract binding.
@@ -32,6 +33,22 @@ type tmplContract struct {
 	Constructor abi.Method             // Contract constructor for deploy parametrization
 	Calls       map[string]*tmplMethod // Contract calls that only read state data
 	Transacts   map[string]*tmplMethod // Contract calls that write state data
+	Events      map[string]*tmplEvent  // Contract events accessors
+}
+
+// tmplStruct is a struct binding a tuple type.
+type tmplStruct struct {
+	Name   string       // Name of the struct, Struct<index> in order of appearance
+	Fields []*tmplField // Fields of the struct, one per tuple component
+
+	index int // Order of appearance, the structs are declared in
+}
+
+// tmplField is a field of a struct binding a tuple type.
+type tmplField struct {
+	Name string // Field name, camel cased the way the abi package names it
+	Type string // Field type bound to the target language
+	Tag  string // Raw component name, set as the json tag like the abi package does
 }
 
 // tmplMethod is a wrapper around an abi.Method that contains a few preprocessed
@@ -42,11 +59,19 @@ type tmplMethod struct {
 	Structured bool       // Whether the returns should be accumulated into a contract
 }
 
+// tmplEvent is a wrapper around an abi.Event that contains a few preprocessed
+// and cached data fields.
+type tmplEvent struct {
+	Original   abi.Event // Original event as parsed by the abi package
+	Normalized abi.Event // Normalized version of the parsed event (capitalized name, non-anonymous inputs)
+}
+
 // tmplSource is language to template mapping containing all the supported
 // programming languages the package can generate to.
 var tmplSource = map[Lang]string{
-	LangGo:   tmplSourceGo,
-	LangJava: tmplSourceJava,
+	LangGo:         tmplSourceGo,
+	LangJava:       tmplSourceJava,
+	LangTypeScript: tmplSourceTypeScript,
 }
 
 // tmplSourceGo is the Go source template use to generate the contract binding
@@ -57,6 +82,25 @@ const tmplSourceGo = `
 
 package {{.Package}}
 
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"ethereum-front/abi"
+	"ethereum-front/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+{{range .Structs}}
+	// {{.Name}} is an auto generated low-level Go binding around a tuple type.
+	type {{.Name}} struct {
+	{{range .Fields}}{{.Name}} {{.Type}} ` + "`" + `json:"{{.Tag}}"` + "`" + `
+	{{end}}}
+{{end}}
+
 {{range $contract := .Contracts}}
 	// {{.Type}}ABI is the input ABI used to generate the binding from.
 	const {{.Type}}ABI = "{{.InputABI}}"
@@ -75,7 +119,7 @@ package {{.Package}}
 		  if err != nil {
 		    return common.Address{}, nil, nil, err
 		  }
-		  return address, tx, &{{.Type}}{ {{.Type}}Caller: {{.Type}}Caller{contract: contract}, {{.Type}}Transactor: {{.Type}}Transactor{contract: contract} }, nil
+		  return address, tx, &{{.Type}}{ {{.Type}}Caller: {{.Type}}Caller{contract: contract}, {{.Type}}Transactor: {{.Type}}Transactor{contract: contract}, {{.Type}}Filterer: {{.Type}}Filterer{contract: contract} }, nil
 		}
 	{{end}}
 
@@ -83,6 +127,7 @@ package {{.Package}}
 	type {{.Type}} struct {
 	  {{.Type}}Caller     // Read-only binding to the contract
 	  {{.Type}}Transactor // Write-only binding to the contract
+	  {{.Type}}Filterer   // Log filterer for contract events
 	}
 
 	// {{.Type}}Caller is an auto generated read-only Go binding around an Ethereum contract.
@@ -95,6 +140,11 @@ package {{.Package}}
 	  contract *bind.BoundContract // Generic contract wrapper for the low level calls
 	}
 
+	// {{.Type}}Filterer is an auto generated log filtering Go binding around an Ethereum contract events.
+	type {{.Type}}Filterer struct {
+	  contract *bind.BoundContract // Generic contract wrapper for the low level calls
+	}
+
 	// {{.Type}}Session is an auto generated Go binding around an Ethereum contract,
 	// with pre-set call and transact options.
 	type {{.Type}}Session struct {
@@ -134,16 +184,16 @@ package {{.Package}}
 
 	// New{{.Type}} creates a new instance of {{.Type}}, bound to a specific deployed contract.
 	func New{{.Type}}(address common.Address, backend bind.ContractBackend) (*{{.Type}}, error) {
-	  contract, err := bind{{.Type}}(address, backend, backend)
+	  contract, err := bind{{.Type}}(address, backend, backend, backend)
 	  if err != nil {
 	    return nil, err
 	  }
-	  return &{{.Type}}{ {{.Type}}Caller: {{.Type}}Caller{contract: contract}, {{.Type}}Transactor: {{.Type}}Transactor{contract: contract} }, nil
+	  return &{{.Type}}{ {{.Type}}Caller: {{.Type}}Caller{contract: contract}, {{.Type}}Transactor: {{.Type}}Transactor{contract: contract}, {{.Type}}Filterer: {{.Type}}Filterer{contract: contract} }, nil
 	}
 
 	// New{{.Type}}Caller creates a new read-only instance of {{.Type}}, bound to a specific deployed contract.
 	func New{{.Type}}Caller(address common.Address, caller bind.ContractCaller) (*{{.Type}}Caller, error) {
-	  contract, err := bind{{.Type}}(address, caller, nil)
+	  contract, err := bind{{.Type}}(address, caller, nil, nil)
 	  if err != nil {
 	    return nil, err
 	  }
@@ -152,20 +202,29 @@ package {{.Package}}
 
 	// New{{.Type}}Transactor creates a new write-only instance of {{.Type}}, bound to a specific deployed contract.
 	func New{{.Type}}Transactor(address common.Address, transactor bind.ContractTransactor) (*{{.Type}}Transactor, error) {
-	  contract, err := bind{{.Type}}(address, nil, transactor)
+	  contract, err := bind{{.Type}}(address, nil, transactor, nil)
 	  if err != nil {
 	    return nil, err
 	  }
 	  return &{{.Type}}Transactor{contract: contract}, nil
 	}
 
+	// New{{.Type}}Filterer creates a new log filterer instance of {{.Type}}, bound to a specific deployed contract.
+	func New{{.Type}}Filterer(address common.Address, filterer bind.ContractFilterer) (*{{.Type}}Filterer, error) {
+	  contract, err := bind{{.Type}}(address, nil, nil, filterer)
+	  if err != nil {
+	    return nil, err
+	  }
+	  return &{{.Type}}Filterer{contract: contract}, nil
+	}
+
 	// bind{{.Type}} binds a generic wrapper to an already deployed contract.
-	func bind{{.Type}}(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor) (*bind.BoundContract, error) {
+	func bind{{.Type}}(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
 	  parsed, err := abi.JSON(strings.NewReader({{.Type}}ABI))
 	  if err != nil {
 	    return nil, err
 	  }
-	  return bind.NewBoundContract(address, parsed, caller, transactor), nil
+	  return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
 	}
 
 	// Call invokes the (constant) contract method with params as input values and
@@ -263,6 +322,128 @@ package {{.Package}}
 		  return _{{$contract.Type}}.Contract.{{.Normalized.Name}}(&_{{$contract.Type}}.TransactOpts {{range $i, $_ := .Normalized.Inputs}}, {{.Name}}{{end}})
 		}
 	{{end}}
+
+	{{range .Events}}
+		// {{$contract.Type}}{{.Normalized.Name}}Iterator is returned from Filter{{.Normalized.Name}} and Watch{{.Normalized.Name}} and is used to iterate over the raw logs and unpacked data for {{.Normalized.Name}} events raised by the {{$contract.Type}} contract.
+		type {{$contract.Type}}{{.Normalized.Name}}Iterator struct {
+			Event *{{$contract.Type}}{{.Normalized.Name}} // Event containing the contract specifics and raw log
+
+			contract *{{$contract.Type}}Filterer // Filterer decoding the events
+			it       *bind.LogIterator          // Generic log iterator of the contract
+			fail     error                      // Occurred error to stop iteration
+		}
+
+		// Next advances the iterator to the subsequent event, returning whether there
+		// are any more events found. In case of a retrieval or parsing error, false is
+		// returned and Error() can be queried for the exact failure.
+		func (it *{{$contract.Type}}{{.Normalized.Name}}Iterator) Next() bool {
+			if it.fail != nil || !it.it.Next() {
+				return false
+			}
+			it.Event, it.fail = it.contract.Parse{{.Normalized.Name}}(it.it.Log)
+			return it.fail == nil
+		}
+
+		// Error returns any retrieval or parsing error occurred during filtering.
+		func (it *{{$contract.Type}}{{.Normalized.Name}}Iterator) Error() error {
+			if it.fail != nil {
+				return it.fail
+			}
+			return it.it.Error()
+		}
+
+		// Close terminates the iteration process, releasing any pending underlying
+		// resources.
+		func (it *{{$contract.Type}}{{.Normalized.Name}}Iterator) Close() error {
+			return it.it.Close()
+		}
+
+		// {{$contract.Type}}{{.Normalized.Name}} represents a {{.Normalized.Name}} event raised by the {{$contract.Type}} contract.
+		type {{$contract.Type}}{{.Normalized.Name}} struct { {{range .Normalized.Inputs}}
+			{{camelcase .Name}} {{if .Indexed}}{{bindtopictype .Type}}{{else}}{{bindtype .Type}}{{end}}; {{end}}
+			Raw types.Log // Blockchain specific contextual infos
+		}
+
+		// Filter{{.Normalized.Name}} is a free log retrieval operation binding the contract event {{.Original.Id.Hex}}.
+		//
+		// Solidity: event {{.Original.Name}}({{range $i, $_ := .Original.Inputs}}{{if ne $i 0}}, {{end}}{{.Type}}{{if .Indexed}} indexed{{end}}{{if .Name}} {{.Name}}{{end}}{{end}})
+		func (_{{$contract.Type}} *{{$contract.Type}}Filterer) Filter{{.Normalized.Name}}(opts *bind.FilterOpts{{range .Normalized.Inputs}}{{if .Indexed}}, {{.Name}} []{{bindtopictype .Type}}{{end}}{{end}}) (*{{$contract.Type}}{{.Normalized.Name}}Iterator, error) {
+			{{range .Normalized.Inputs}}
+			{{if .Indexed}}var {{.Name}}Rule []interface{}
+			for _, {{.Name}}Item := range {{.Name}} {
+				{{.Name}}Rule = append({{.Name}}Rule, {{.Name}}Item)
+			}{{end}}{{end}}
+
+			it, err := _{{$contract.Type}}.contract.FilterLogs(opts, "{{.Original.Name}}"{{range .Normalized.Inputs}}{{if .Indexed}}, {{.Name}}Rule{{end}}{{end}})
+			if err != nil {
+				return nil, err
+			}
+			return &{{$contract.Type}}{{.Normalized.Name}}Iterator{contract: _{{$contract.Type}}, it: it}, nil
+		}
+
+		// Watch{{.Normalized.Name}} is a free log subscription operation binding the contract event {{.Original.Id.Hex}}.
+		//
+		// Solidity: event {{.Original.Name}}({{range $i, $_ := .Original.Inputs}}{{if ne $i 0}}, {{end}}{{.Type}}{{if .Indexed}} indexed{{end}}{{if .Name}} {{.Name}}{{end}}{{end}})
+		func (_{{$contract.Type}} *{{$contract.Type}}Filterer) Watch{{.Normalized.Name}}(opts *bind.WatchOpts, sink chan<- *{{$contract.Type}}{{.Normalized.Name}}{{range .Normalized.Inputs}}{{if .Indexed}}, {{.Name}} []{{bindtopictype .Type}}{{end}}{{end}}) (event.Subscription, error) {
+			{{range .Normalized.Inputs}}
+			{{if .Indexed}}var {{.Name}}Rule []interface{}
+			for _, {{.Name}}Item := range {{.Name}} {
+				{{.Name}}Rule = append({{.Name}}Rule, {{.Name}}Item)
+			}{{end}}{{end}}
+
+			it, err := _{{$contract.Type}}.contract.WatchLogs(opts, "{{.Original.Name}}"{{range .Normalized.Inputs}}{{if .Indexed}}, {{.Name}}Rule{{end}}{{end}})
+			if err != nil {
+				return nil, err
+			}
+			return event.NewSubscription(func(quit <-chan struct{}) error {
+				defer it.Close()
+
+				// Closing the iterator on unsubscribe stops a pending Next
+				done := make(chan struct{})
+				defer close(done)
+				go func() {
+					select {
+					case <-quit:
+						it.Close()
+					case <-done:
+					}
+				}()
+
+				for it.Next() {
+					// New log arrived, parse the event and forward to the user
+					event, err := _{{$contract.Type}}.Parse{{.Normalized.Name}}(it.Log)
+					if err != nil {
+						return err
+					}
+					select {
+					case sink <- event:
+					case <-quit:
+						return nil
+					}
+				}
+				return it.Error()
+			}), nil
+		}
+
+		// Parse{{.Normalized.Name}} is a log parse operation binding the contract event {{.Original.Id.Hex}}.
+		//
+		// Solidity: event {{.Original.Name}}({{range $i, $_ := .Original.Inputs}}{{if ne $i 0}}, {{end}}{{.Type}}{{if .Indexed}} indexed{{end}}{{if .Name}} {{.Name}}{{end}}{{end}})
+		func (_{{$contract.Type}} *{{$contract.Type}}Filterer) Parse{{.Normalized.Name}}(log types.Log) (*{{$contract.Type}}{{.Normalized.Name}}, error) {
+			values := make(map[string]interface{})
+			if err := _{{$contract.Type}}.contract.UnpackLogIntoMap(values, "{{.Original.Name}}", log); err != nil {
+				return nil, err
+			}
+			event := &{{$contract.Type}}{{.Normalized.Name}}{Raw: log}
+			{{if .Normalized.Inputs}}var ok bool{{end}}
+			{{$event := .}}{{range $i, $_ := .Normalized.Inputs}}{{$key := argname $event.Original.Inputs $i}}{{if and (not .Indexed) (hastuple .Type)}}if ok = abi.Convert(&event.{{camelcase .Name}}, values["{{$key}}"]) == nil; !ok {
+				return nil, fmt.Errorf("{{$contract.Type}}: unexpected {{$key}} value %T", values["{{$key}}"])
+			}{{else}}if event.{{camelcase .Name}}, ok = values["{{$key}}"].({{if .Indexed}}{{bindtopictype .Type}}{{else}}{{bindtype .Type}}{{end}}); !ok {
+				return nil, fmt.Errorf("{{$contract.Type}}: unexpected {{$key}} value %T", values["{{$key}}"])
+			}{{end}}
+			{{end}}
+			return event, nil
+		}
+	{{end}}
 {{end}}
 `
 
@@ -364,6 +545,155 @@ import org.ethereum.geth.internal.*;
 				return this.Contract.transact(opts, "{{.Original.Name}}"	, args);
 			}
 		{{end}}
+
+		{{range .Events}}
+			// {{capitalise .Normalized.Name}}Listener receives the raw logs of the {{.Original.Name}} event.
+			public interface {{capitalise .Normalized.Name}}Listener extends FilterLogsHandler {}
+
+			// {{.Normalized.Name}}Query is the log filter query matching the contract event {{.Original.Id.Hex}}.
+			//
+			// Solidity: event {{.Original.Name}}({{range $i, $_ := .Original.Inputs}}{{if ne $i 0}}, {{end}}{{.Type}}{{if .Indexed}} indexed{{end}}{{if .Name}} {{.Name}}{{end}}{{end}})
+			private FilterQuery {{.Normalized.Name}}Query() throws Exception {
+				FilterQuery query = Geth.newFilterQuery();
+				Addresses addresses = Geth.newAddresses(1); addresses.set(0, this.Address);
+				query.setAddresses(addresses);
+				{{if not .Original.Anonymous}}
+					Hashes hashes = Geth.newHashes(1); hashes.set(0, Geth.newHashFromHex("{{.Original.Id.Hex}}"));
+					Topics topics = Geth.newTopics(1); topics.set(0, hashes);
+					query.setTopics(topics);
+				{{end}}
+				return query;
+			}
+
+			// filter{{capitalise .Normalized.Name}} retrieves the logs of the {{.Original.Name}} event.
+			public Logs filter{{capitalise .Normalized.Name}}(Context ctx, EthereumClient client) throws Exception {
+				return client.filterLogs(ctx, this.{{.Normalized.Name}}Query());
+			}
+
+			// watch{{capitalise .Normalized.Name}} subscribes to the logs of the {{.Original.Name}} event.
+			public Subscription watch{{capitalise .Normalized.Name}}(Context ctx, EthereumClient client, {{capitalise .Normalized.Name}}Listener listener) throws Exception {
+				return client.subscribeFilterLogs(ctx, this.{{.Normalized.Name}}Query(), listener, 16);
+			}
+		{{end}}
 	}
 {{end}}
 `
+
+// tmplSourceTypeScript is the TypeScript source template use to generate the
+// contract binding based on. The bindings are transport agnostic: they call into
+// a ContractRunner, which encodes, signs and decodes with the library of choice.
+const tmplSourceTypeScript = `
+// This file is an automatically generated TypeScript binding of the {{.Package}}
+// contracts. Do not modify as any change will likely be lost upon the next
+// re-generation!
+
+// CallOptions are the options of a constant method call.
+export interface CallOptions {
+  from?: ` + "`0x${string}`" + `;
+  blockNumber?: bigint | "latest" | "pending";
+}
+
+// TransactOptions are the options of a transaction, left to the runner if not set.
+export interface TransactOptions {
+  from?: ` + "`0x${string}`" + `;
+  value?: bigint;
+  gasLimit?: bigint;
+  gasPrice?: bigint;
+  nonce?: bigint;
+}
+
+// LogFilter is the block range logs are retrieved from.
+export interface LogFilter {
+  fromBlock?: bigint;
+  toBlock?: bigint;
+}
+
+// Log is a contract event log as found in a block.
+export interface Log {
+  address: ` + "`0x${string}`" + `;
+  topics: ` + "`0x${string}`" + `[];
+  data: ` + "`0x${string}`" + `;
+  blockNumber: bigint;
+  blockHash: ` + "`0x${string}`" + `;
+  transactionHash: ` + "`0x${string}`" + `;
+  logIndex: number;
+}
+
+// ContractRunner carries out the contract operations of the bindings. Methods
+// are given by their signature, e.g. transfer(address,uint256), which tells
+// overloads apart. Values are passed in and returned in the order of the abi
+// arguments; topics hold the accepted values of every indexed event input, an
+// empty list matching any.
+export interface ContractRunner {
+  call(address: ` + "`0x${string}`" + `, abi: string, method: string, args: unknown[], opts?: CallOptions): Promise<unknown[]>;
+  transact(address: ` + "`0x${string}`" + `, abi: string, method: string, args: unknown[], opts?: TransactOptions): Promise<` + "`0x${string}`" + `>;
+  deploy(abi: string, bytecode: ` + "`0x${string}`" + `, args: unknown[], opts?: TransactOptions): Promise<{ address: ` + "`0x${string}`" + `; hash: ` + "`0x${string}`" + ` }>;
+  filterLogs(address: ` + "`0x${string}`" + `, abi: string, event: string, topics: unknown[][], filter?: LogFilter): Promise<{ values: unknown[]; log: Log }[]>;
+  watchLogs(address: ` + "`0x${string}`" + `, abi: string, event: string, topics: unknown[][], onLog: (values: unknown[], log: Log) => void, onError?: (error: Error) => void): () => void;
+}
+{{range $contract := .Contracts}}
+// {{.Type}}ABI is the input ABI used to generate the binding from.
+export const {{.Type}}ABI = "{{.InputABI}}";
+{{if .InputBin}}
+// {{.Type}}Bin is the compiled bytecode used for deploying new contracts.
+export const {{.Type}}Bin = ("0x" + "{{.InputBin}}".replace(/^0x/, "")) as ` + "`0x${string}`" + `;
+{{end}}
+{{range .Events}}
+// {{$contract.Type}}{{capitalise .Normalized.Name}} represents a {{.Original.Name}} event raised by the {{$contract.Type}} contract.
+export interface {{$contract.Type}}{{capitalise .Normalized.Name}} {
+  {{range .Normalized.Inputs}}{{.Name}}: {{if .Indexed}}{{bindtopictype .Type}}{{else}}{{bindtype .Type}}{{end}};
+  {{end}}raw: Log;
+}
+{{end}}
+// {{.Type}} is an auto generated TypeScript binding around an Ethereum contract.
+export class {{.Type}} {
+  constructor(readonly address: ` + "`0x${string}`" + `, readonly runner: ContractRunner) {}
+{{if .InputBin}}
+  // deploy deploys a new Ethereum contract, binding an instance of {{.Type}} to it.
+  static async deploy(runner: ContractRunner, {{range .Constructor.Inputs}}{{.Name}}: {{bindtype .Type}}, {{end}}opts?: TransactOptions): Promise<{ contract: {{.Type}}; hash: ` + "`0x${string}`" + ` }> {
+    const { address, hash } = await runner.deploy({{.Type}}ABI, {{.Type}}Bin, [{{range .Constructor.Inputs}}{{.Name}}, {{end}}], opts);
+    return { contract: new {{.Type}}(address, runner), hash };
+  }
+{{end}}
+{{range .Calls}}
+  // {{.Normalized.Name}} is a free data retrieval call binding the contract method 0x{{printf "%x" .Original.Id}}.
+  //
+  // Solidity: {{.Original.String}}
+  async {{.Normalized.Name}}({{range .Normalized.Inputs}}{{.Name}}: {{bindtype .Type}}, {{end}}opts?: CallOptions): Promise<{{if gt (len .Original.Outputs) 1}}{ {{range $i, $_ := .Original.Outputs}}{{if .Name}}{{.Name}}{{else}}return{{$i}}{{end}}: {{bindtype .Type}}; {{end}}}{{else}}{{range .Original.Outputs}}{{bindtype .Type}}{{else}}void{{end}}{{end}}> {
+    {{if .Original.Outputs}}const out = {{end}}await this.runner.call(this.address, {{$contract.Type}}ABI, "{{.Original.Sig}}", [{{range .Normalized.Inputs}}{{.Name}}, {{end}}], opts);
+    {{if gt (len .Original.Outputs) 1}}return { {{range $i, $_ := .Original.Outputs}}{{if .Name}}{{.Name}}{{else}}return{{$i}}{{end}}: out[{{$i}}] as {{bindtype .Type}}, {{end}}};{{else}}{{range .Original.Outputs}}return out[0] as {{bindtype .Type}};{{end}}{{end}}
+  }
+{{end}}
+{{range .Transacts}}
+  // {{.Normalized.Name}} is a paid mutator transaction binding the contract method 0x{{printf "%x" .Original.Id}}.
+  //
+  // Solidity: {{.Original.String}}
+  async {{.Normalized.Name}}({{range .Normalized.Inputs}}{{.Name}}: {{bindtype .Type}}, {{end}}opts?: TransactOptions): Promise<` + "`0x${string}`" + `> {
+    return this.runner.transact(this.address, {{$contract.Type}}ABI, "{{.Original.Sig}}", [{{range .Normalized.Inputs}}{{.Name}}, {{end}}], opts);
+  }
+{{end}}
+{{range .Events}}
+  // filter{{capitalise .Normalized.Name}} is a free log retrieval operation binding the contract event {{.Original.Id.Hex}}.
+  //
+  // Solidity: event {{.Original.Name}}({{range $i, $_ := .Original.Inputs}}{{if ne $i 0}}, {{end}}{{.Type}}{{if .Indexed}} indexed{{end}}{{if .Name}} {{.Name}}{{end}}{{end}})
+  async filter{{capitalise .Normalized.Name}}({{range .Normalized.Inputs}}{{if .Indexed}}{{.Name}}: {{bindtopictype .Type}}[] = [], {{end}}{{end}}filter?: LogFilter): Promise<{{$contract.Type}}{{capitalise .Normalized.Name}}[]> {
+    const logs = await this.runner.filterLogs(this.address, {{$contract.Type}}ABI, "{{.Original.Name}}", [{{range .Normalized.Inputs}}{{if .Indexed}}{{.Name}}, {{end}}{{end}}], filter);
+    return logs.map(({ values, log }) => this.parse{{capitalise .Normalized.Name}}(values, log));
+  }
+
+  // watch{{capitalise .Normalized.Name}} is a free log subscription operation binding the contract event {{.Original.Id.Hex}},
+  // returning the function cancelling the subscription.
+  //
+  // Solidity: event {{.Original.Name}}({{range $i, $_ := .Original.Inputs}}{{if ne $i 0}}, {{end}}{{.Type}}{{if .Indexed}} indexed{{end}}{{if .Name}} {{.Name}}{{end}}{{end}})
+  watch{{capitalise .Normalized.Name}}(onEvent: (event: {{$contract.Type}}{{capitalise .Normalized.Name}}) => void, {{range .Normalized.Inputs}}{{if .Indexed}}{{.Name}}: {{bindtopictype .Type}}[] = [], {{end}}{{end}}onError?: (error: Error) => void): () => void {
+    return this.runner.watchLogs(this.address, {{$contract.Type}}ABI, "{{.Original.Name}}", [{{range .Normalized.Inputs}}{{if .Indexed}}{{.Name}}, {{end}}{{end}}], (values, log) => onEvent(this.parse{{capitalise .Normalized.Name}}(values, log)), onError);
+  }
+
+  // parse{{capitalise .Normalized.Name}} assembles a {{.Original.Name}} event out of its decoded input values.
+  parse{{capitalise .Normalized.Name}}(values: unknown[], log: Log): {{$contract.Type}}{{capitalise .Normalized.Name}} {
+    return { {{range $i, $_ := .Normalized.Inputs}}{{.Name}}: values[{{$i}}] as {{if .Indexed}}{{bindtopictype .Type}}{{else}}{{bindtype .Type}}{{end}}, {{end}}raw: log };
+  }
+{{end}}
+}
+{{end}}
+`
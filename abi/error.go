@@ -70,7 +70,9 @@ func typeCheck(t Type, value reflect.Value) error {
 	// Check base type validity. Element types will be checked later on.
 	if t.Kind != value.Kind() {
 		return typeErr(t.Kind, value.Kind())
-	} else if t.T == FixedBytesTy && t.Size != value.Len() {
+	} else if (t.T == FixedBytesTy || t.T == FunctionTy) && t.Size != value.Len() {
+		return typeErr(t.Type, value.Type())
+	} else if t.T == FixedPointTy && value.Type() != t.Type {
 		return typeErr(t.Type, value.Type())
 	} else {
 		return nil
@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
@@ -65,43 +66,45 @@ func (e Event) tupleUnpack(v interface{}, output []byte) error {
 		return fmt.Errorf("abi: cannot unmarshal tuple in to %v", typ)
 	}
 
-	j := 0
-	for i := 0; i < len(e.Inputs); i++ {
-		input := e.Inputs[i]
-		if input.Indexed {
-			// can't read, continue
-			continue
-		} else if input.Type.T == ArrayTy {
-			// need to move this up because they read sequentially
-			j += input.Type.Size
+	// indexed inputs can't be read from the data, so they need no field
+	var inputs []Argument
+	for _, input := range e.Inputs {
+		if !input.Indexed {
+			inputs = append(inputs, input)
 		}
-		marshalledValue, err := toGoType((i+j)*32, input.Type, output)
+	}
+	fields, err := structFieldMap(inputs, typ)
+	if err != nil {
+		return err
+	}
+
+	offset := 0
+	for i, input := range inputs {
+		marshalledValue, err := toGoType(offset, input.Type, output)
 		if err != nil {
 			return err
 		}
+		// static arrays and tuples are laid out in place, spanning several words
+		offset += getTypeSize(input.Type)
 		reflectValue := reflect.ValueOf(marshalledValue)
 
 		switch value.Kind() {
 		case reflect.Struct:
-			for j := 0; j < typ.NumField(); j++ {
-				field := typ.Field(j)
-				// TODO read tags: `abi:"fieldName"`
-				if field.Name == strings.ToUpper(e.Inputs[i].Name[:1])+e.Inputs[i].Name[1:] {
-					if err := set(value.Field(j), reflectValue, e.Inputs[i]); err != nil {
-						return err
-					}
+			if j, ok := fields[i]; ok {
+				if err := set(value.Field(j), reflectValue, input); err != nil {
+					return err
 				}
 			}
 		case reflect.Slice, reflect.Array:
 			if value.Len() < i {
-				return fmt.Errorf("abi: insufficient number of arguments for unpack, want %d, got %d", len(e.Inputs), value.Len())
+				return fmt.Errorf("abi: insufficient number of arguments for unpack, want %d, got %d", len(inputs), value.Len())
 			}
 			v := value.Index(i)
 			if v.Kind() != reflect.Ptr && v.Kind() != reflect.Interface {
 				return fmt.Errorf("abi: cannot unmarshal %v in to %v", v.Type(), reflectValue.Type())
 			}
 			reflectValue := reflect.ValueOf(marshalledValue)
-			if err := set(v.Elem(), reflectValue, e.Inputs[i]); err != nil {
+			if err := set(v.Elem(), reflectValue, input); err != nil {
 				return err
 			}
 		default:
@@ -145,3 +148,138 @@ func (e Event) singleUnpack(v interface{}, output []byte) error {
 	}
 	return nil
 }
+
+// ParseLog decodes a log of the event: the indexed inputs are read from the
+// log topics and the others from the log data. The values are returned keyed
+// by input name, or `arg<index>` for unnamed inputs.
+//
+// Indexed inputs of dynamic or composite types (strings, bytes, arrays and
+// tuples) are only logged as the keccak256 hash of their encoding, so they
+// are returned as that common.Hash.
+func (e Event) ParseLog(log types.Log) (map[string]interface{}, error) {
+	values, err := e.unpackLog(log)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]interface{}, len(values))
+	for i, value := range values {
+		result[ArgumentName(e.Inputs, i)] = value
+	}
+	return result, nil
+}
+
+// unpackLog decodes all the inputs of the event out of a log, in order.
+func (e Event) unpackLog(log types.Log) ([]interface{}, error) {
+	topics := log.Topics
+	if !e.Anonymous {
+		if len(topics) == 0 || topics[0] != e.Id() {
+			return nil, fmt.Errorf("abi: log is not a %s event", e.Name)
+		}
+		topics = topics[1:]
+	}
+	var nonIndexed []Argument
+	for _, input := range e.Inputs {
+		if !input.Indexed {
+			nonIndexed = append(nonIndexed, input)
+		}
+	}
+	if indexed := len(e.Inputs) - len(nonIndexed); len(topics) != indexed {
+		return nil, fmt.Errorf("abi: event %s has %d indexed inputs, log has %d topics", e.Name, indexed, len(topics))
+	}
+	data, err := unpackArguments(nonIndexed, log.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]interface{}, len(e.Inputs))
+	for i, input := range e.Inputs {
+		if !input.Indexed {
+			values[i], data = data[0], data[1:]
+			continue
+		}
+		if values[i], err = parseTopic(input.Type, topics[0]); err != nil {
+			return nil, err
+		}
+		topics = topics[1:]
+	}
+	return values, nil
+}
+
+// parseTopic decodes the value of an indexed input from its topic. Only
+// value types are stored as is, everything else is stored hashed.
+func parseTopic(t Type, topic common.Hash) (interface{}, error) {
+	switch t.T {
+	case IntTy, UintTy, BoolTy, AddressTy, FixedBytesTy, FixedPointTy, FunctionTy:
+		return toGoType(0, t, topic[:])
+	}
+	return topic, nil
+}
+
+// Topics builds the topic filter of a log query for the event. The query has
+// an entry per indexed input, in order, listing the values accepted for it;
+// a nil or empty entry matches any value. The event id is prepended unless
+// the event is anonymous.
+//
+// Values of indexed strings and bytes are hashed. Arrays and tuples are only
+// matched by the common.Hash of their encoding, which may also be given for
+// any other input.
+func (e Event) Topics(query ...[]interface{}) ([][]common.Hash, error) {
+	var indexed []Argument
+	for _, input := range e.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+	if len(query) > len(indexed) {
+		return nil, fmt.Errorf("abi: event %s has %d indexed inputs, got %d filters", e.Name, len(indexed), len(query))
+	}
+
+	var topics [][]common.Hash
+	if !e.Anonymous {
+		topics = append(topics, []common.Hash{e.Id()})
+	}
+	for i, values := range query {
+		var topic []common.Hash
+		for _, value := range values {
+			hash, err := makeTopic(indexed[i].Type, value)
+			if err != nil {
+				return nil, fmt.Errorf("abi: filter on %s: %v", ArgumentName(indexed, i), err)
+			}
+			topic = append(topic, hash)
+		}
+		topics = append(topics, topic)
+	}
+	// trailing wildcards are implied
+	for len(topics) > 0 && len(topics[len(topics)-1]) == 0 {
+		topics = topics[:len(topics)-1]
+	}
+	return topics, nil
+}
+
+// makeTopic encodes the value of an indexed input the way the EVM logs it.
+func makeTopic(t Type, value interface{}) (common.Hash, error) {
+	if hash, ok := value.(common.Hash); ok {
+		return hash, nil
+	}
+	switch t.T {
+	case StringTy:
+		s, ok := value.(string)
+		if !ok {
+			return common.Hash{}, fmt.Errorf("%v is not %v", value, t)
+		}
+		return crypto.Keccak256Hash([]byte(s)), nil
+	case BytesTy:
+		b, ok := value.([]byte)
+		if !ok {
+			return common.Hash{}, fmt.Errorf("%v is not %v", value, t)
+		}
+		return crypto.Keccak256Hash(b), nil
+	case SliceTy, ArrayTy, TupleTy:
+		return common.Hash{}, fmt.Errorf("%v values are matched by their hash only", t)
+	}
+	word, err := t.pack(reflect.ValueOf(value))
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(word), nil
+}
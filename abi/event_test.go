@@ -17,10 +17,13 @@
 package abi
 
 import (
+	"math/big"
+	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
@@ -54,3 +57,190 @@ func TestEventId(t *testing.T) {
 		}
 	}
 }
+
+const logJSON = `[
+	{ "type" : "event", "name" : "Transfer", "inputs" : [ { "name" : "from", "type" : "address", "indexed" : true }, { "name" : "to", "type" : "address", "indexed" : true }, { "name" : "value", "type" : "uint256" } ] },
+	{ "type" : "event", "name" : "Named", "inputs" : [ { "name" : "key", "type" : "string", "indexed" : true }, { "name" : "", "type" : "string" }, { "name" : "flag", "type" : "bool", "indexed" : true } ] },
+	{ "type" : "event", "name" : "Anon", "anonymous" : true, "inputs" : [ { "name" : "id", "type" : "uint8", "indexed" : true } ] }
+]`
+
+func TestUnpackLog(t *testing.T) {
+	abi, err := JSON(strings.NewReader(logJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	from := common.HexToAddress("0x376c47978271565f56DEB45495afa69E59c16Ab2")
+	to := common.HexToAddress("0x0000000000000000000000000000000000000001")
+
+	log := types.Log{
+		Topics: []common.Hash{
+			crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)")),
+			common.BytesToHash(from[:]),
+			common.BytesToHash(to[:]),
+		},
+		Data: common.LeftPadBytes(big.NewInt(1000).Bytes(), 32),
+	}
+
+	var transfer struct {
+		From  common.Address
+		To    common.Address
+		Value *big.Int
+	}
+	if err := abi.UnpackLog(&transfer, "Transfer", log); err != nil {
+		t.Fatal(err)
+	}
+	if transfer.From != from || transfer.To != to || transfer.Value.Cmp(big.NewInt(1000)) != 0 {
+		t.Errorf("struct mismatch: %+v", transfer)
+	}
+
+	values := make(map[string]interface{})
+	if err := abi.UnpackLogIntoMap(values, "Transfer", log); err != nil {
+		t.Fatal(err)
+	}
+	exp := map[string]interface{}{"from": from, "to": to, "value": big.NewInt(1000)}
+	if !reflect.DeepEqual(values, exp) {
+		t.Errorf("map mismatch: have %v, want %v", values, exp)
+	}
+
+	var tagged struct {
+		Sender common.Address `abi:"from"`
+		To     common.Address
+		Amount *big.Int `abi:"value"`
+	}
+	if err := abi.UnpackLog(&tagged, "Transfer", log); err != nil {
+		t.Fatal(err)
+	}
+	if tagged.Sender != from || tagged.To != to || tagged.Amount.Cmp(big.NewInt(1000)) != 0 {
+		t.Errorf("tagged struct mismatch: %+v", tagged)
+	}
+
+	if event, err := abi.EventById(log.Topics[0]); err != nil || event.Name != "Transfer" {
+		t.Errorf("lookup by id failed: %v", err)
+	}
+	if err := abi.UnpackLog(&transfer, "Named", log); err == nil {
+		t.Error("expected error for mismatching event id")
+	}
+	log.Topics = log.Topics[:2]
+	if err := abi.UnpackLog(&transfer, "Transfer", log); err == nil {
+		t.Error("expected error for missing topic")
+	}
+}
+
+func TestParseLogIndexedDynamic(t *testing.T) {
+	abi, err := JSON(strings.NewReader(logJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyHash := crypto.Keccak256Hash([]byte("key"))
+	log := types.Log{
+		Topics: []common.Hash{
+			crypto.Keccak256Hash([]byte("Named(string,string,bool)")),
+			keyHash,
+			common.BytesToHash([]byte{1}),
+		},
+		Data: common.Hex2Bytes("0000000000000000000000000000000000000000000000000000000000000020" +
+			"0000000000000000000000000000000000000000000000000000000000000005" +
+			"68656c6c6f000000000000000000000000000000000000000000000000000000"),
+	}
+	values, err := abi.Events["Named"].ParseLog(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := map[string]interface{}{"key": keyHash, "arg1": "hello", "flag": true}
+	if !reflect.DeepEqual(values, exp) {
+		t.Errorf("map mismatch: have %v, want %v", values, exp)
+	}
+
+	// anonymous events have no id topic
+	values, err = abi.Events["Anon"].ParseLog(types.Log{Topics: []common.Hash{common.BytesToHash([]byte{7})}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if values["id"] != uint8(7) {
+		t.Errorf("anonymous value mismatch: have %v, want 7", values["id"])
+	}
+}
+
+func TestParseLogIndexedFixedFunction(t *testing.T) {
+	abi, err := JSON(strings.NewReader(`[{ "type" : "event", "name" : "Priced", "inputs" : [ { "name" : "price", "type" : "fixed128x2", "indexed" : true }, { "name" : "callback", "type" : "function", "indexed" : true } ] }]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	event := abi.Events["Priced"]
+	price := NewFixed(big.NewInt(-150), 2)
+	callback := [24]byte{19: 1, 20: 0xa9, 0x05, 0x9c, 0xbb}
+
+	topics, err := event.Topics([]interface{}{price}, []interface{}{callback})
+	if err != nil {
+		t.Fatal(err)
+	}
+	values, err := event.ParseLog(types.Log{Topics: []common.Hash{topics[0][0], topics[1][0], topics[2][0]}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, ok := values["price"].(*Fixed); !ok || have.String() != "-1.50" {
+		t.Errorf("price mismatch: have %v (%T)", values["price"], values["price"])
+	}
+	if have, ok := values["callback"].([24]byte); !ok || have != callback {
+		t.Errorf("callback mismatch: have %v (%T)", values["callback"], values["callback"])
+	}
+}
+
+func TestEventTopics(t *testing.T) {
+	const definition = `[
+	{ "type" : "event", "name" : "Transfer", "inputs": [{ "name": "from", "type": "address", "indexed": true }, { "name": "to", "type": "address", "indexed": true }, { "name": "value", "type": "uint256" }] },
+	{ "type" : "event", "name" : "Named", "anonymous": true, "inputs": [{ "name": "name", "type": "string", "indexed": true }, { "name": "id", "type": "int8", "indexed": true }] }
+	]`
+	abi, err := JSON(strings.NewReader(definition))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	from := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	topics, err := abi.Events["Transfer"].Topics([]interface{}{from})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]common.Hash{
+		{crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))},
+		{common.BytesToHash(from.Bytes())},
+	}
+	if !reflect.DeepEqual(topics, want) {
+		t.Errorf("expected %v, got %v", want, topics)
+	}
+
+	// wildcards in between are kept, trailing ones are dropped
+	topics, err = abi.Events["Transfer"].Topics(nil, []interface{}{from})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(topics) != 3 || topics[1] != nil || topics[2][0] != common.BytesToHash(from.Bytes()) {
+		t.Errorf("unexpected topics %v", topics)
+	}
+	topics, err = abi.Events["Transfer"].Topics([]interface{}{from}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(topics) != 2 {
+		t.Errorf("expected the trailing wildcard dropped, got %v", topics)
+	}
+
+	topics, err = abi.Events["Named"].Topics([]interface{}{"a", "b"}, []interface{}{int8(-1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = [][]common.Hash{
+		{crypto.Keccak256Hash([]byte("a")), crypto.Keccak256Hash([]byte("b"))},
+		{common.BytesToHash(common.FromHex("0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))},
+	}
+	if !reflect.DeepEqual(topics, want) {
+		t.Errorf("expected %v, got %v", want, topics)
+	}
+
+	if _, err := abi.Events["Transfer"].Topics(nil, nil, []interface{}{big.NewInt(1)}); err == nil {
+		t.Error("expected an error filtering on a non indexed input")
+	}
+	if _, err := abi.Events["Named"].Topics([]interface{}{1}); err == nil {
+		t.Error("expected an error filtering a string on an int")
+	}
+}
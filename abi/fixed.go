@@ -0,0 +1,183 @@
+// Copyright 2015 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	fixed_t = reflect.TypeOf(&Fixed{})
+	big10   = big.NewInt(10)
+)
+
+// Fixed is the Go representation of the fixedMxN and ufixedMxN abi types: a
+// decimal number stored as the integer Value scaled by 10^Decimals, which is
+// exactly how the number is encoded on chain.
+type Fixed struct {
+	Value    *big.Int
+	Decimals int
+}
+
+// NewFixed creates a fixed point number out of its scaled integer value, i.e.
+// NewFixed(big.NewInt(15), 1) is 1.5.
+func NewFixed(value *big.Int, decimals int) *Fixed {
+	return &Fixed{Value: value, Decimals: decimals}
+}
+
+// ParseFixed parses a decimal string such as "-1.25" into a fixed point number
+// with the given amount of decimals. Digits beyond the decimals are rejected
+// rather than silently rounded.
+func ParseFixed(s string, decimals int) (*Fixed, error) {
+	text := strings.TrimSpace(s)
+	sign := ""
+	if strings.HasPrefix(text, "-") || strings.HasPrefix(text, "+") {
+		sign, text = text[:1], text[1:]
+	}
+	whole, frac := text, ""
+	if i := strings.Index(text, "."); i >= 0 {
+		whole, frac = text[:i], text[i+1:]
+	}
+	if whole == "" && frac == "" {
+		return nil, fmt.Errorf("abi: invalid fixed point number %q", s)
+	}
+	if len(frac) > decimals {
+		if strings.Trim(frac[decimals:], "0") != "" {
+			return nil, fmt.Errorf("abi: %q has more than %d decimals", s, decimals)
+		}
+		frac = frac[:decimals]
+	}
+	digits := whole + frac + strings.Repeat("0", decimals-len(frac))
+	if strings.ContainsAny(digits, "+-") {
+		return nil, fmt.Errorf("abi: invalid fixed point number %q", s)
+	}
+	value, ok := new(big.Int).SetString(sign+digits, 10)
+	if !ok {
+		return nil, fmt.Errorf("abi: invalid fixed point number %q", s)
+	}
+	return NewFixed(value, decimals), nil
+}
+
+// Float returns the number as an arbitrary precision float.
+func (f *Fixed) Float() *big.Float {
+	scale := new(big.Float).SetInt(new(big.Int).Exp(big10, big.NewInt(int64(f.Decimals)), nil))
+	return new(big.Float).Quo(new(big.Float).SetInt(f.Value), scale)
+}
+
+// String returns the exact decimal representation of the number, e.g. "-1.50".
+func (f *Fixed) String() string {
+	if f == nil || f.Value == nil {
+		return "<nil>"
+	}
+	digits := new(big.Int).Abs(f.Value).String()
+	if f.Decimals > 0 {
+		if len(digits) <= f.Decimals {
+			digits = strings.Repeat("0", f.Decimals-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-f.Decimals] + "." + digits[len(digits)-f.Decimals:]
+	}
+	if f.Value.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// MarshalText encodes the number as its exact decimal representation.
+func (f *Fixed) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// scaled returns the integer value of f rescaled to the given amount of
+// decimals, failing if that would lose precision.
+func (f *Fixed) scaled(decimals int) (*big.Int, error) {
+	if f.Value == nil {
+		return nil, fmt.Errorf("abi: nil fixed point value")
+	}
+	switch {
+	case f.Decimals == decimals:
+		return f.Value, nil
+	case f.Decimals < decimals:
+		scale := new(big.Int).Exp(big10, big.NewInt(int64(decimals-f.Decimals)), nil)
+		return new(big.Int).Mul(f.Value, scale), nil
+	}
+	scale := new(big.Int).Exp(big10, big.NewInt(int64(f.Decimals-decimals)), nil)
+	value, rem := new(big.Int).QuoRem(f.Value, scale, new(big.Int))
+	if rem.Sign() != 0 {
+		return nil, fmt.Errorf("abi: %s has more than %d decimals", f, decimals)
+	}
+	return value, nil
+}
+
+// packFixed packs a fixed point number as the two's complement of its value
+// scaled to the decimals of t, checking it fits the M bits of the type.
+func packFixed(t Type, v reflect.Value) ([]byte, error) {
+	if err := typeCheck(t, v); err != nil {
+		return nil, err
+	}
+	f := v.Interface().(*Fixed)
+	if f == nil {
+		return nil, fmt.Errorf("abi: nil fixed point value")
+	}
+	value, err := f.scaled(t.Decimals)
+	if err != nil {
+		return nil, err
+	}
+	var min, max *big.Int
+	if t.unsignedFixed() {
+		min, max = common.Big0, new(big.Int).Lsh(common.Big1, uint(t.Size))
+	} else {
+		max = new(big.Int).Lsh(common.Big1, uint(t.Size-1))
+		min = new(big.Int).Neg(max)
+	}
+	if value.Cmp(min) < 0 || value.Cmp(max) >= 0 {
+		return nil, fmt.Errorf("abi: %s overflows %s", f, t)
+	}
+	return U256(new(big.Int).Set(value)), nil
+}
+
+// readFixed decodes a fixed point number out of a 32 byte word.
+func readFixed(t Type, word []byte) (*Fixed, error) {
+	if t.T != FixedPointTy {
+		return nil, fmt.Errorf("abi: invalid type in call to make fixed point number.")
+	}
+	value := new(big.Int).SetBytes(word)
+	if !t.unsignedFixed() && word[0]&0x80 != 0 {
+		value.Sub(value, new(big.Int).Lsh(common.Big1, 256))
+	}
+	return NewFixed(value, t.Decimals), nil
+}
+
+// unsignedFixed reports whether t is an ufixedMxN type.
+func (t Type) unsignedFixed() bool {
+	return strings.HasPrefix(t.stringKind, "ufixed")
+}
+
+// NewFunction builds the value of an abi function type, which is the address
+// of the contract followed by the 4 byte selector of the function.
+func NewFunction(address common.Address, selector []byte) (fn [24]byte, err error) {
+	if len(selector) != 4 {
+		return fn, fmt.Errorf("abi: function selector must be 4 bytes, got %d", len(selector))
+	}
+	copy(fn[:20], address[:])
+	copy(fn[20:], selector)
+	return fn, nil
+}
@@ -0,0 +1,106 @@
+// Copyright 2015 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"bytes"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestParseFixed(t *testing.T) {
+	for i, test := range []struct {
+		input    string
+		decimals int
+		value    int64
+		str      string
+		err      string
+	}{
+		{"1.5", 2, 150, "1.50", ""},
+		{"-1.5", 1, -15, "-1.5", ""},
+		{"+.25", 2, 25, "0.25", ""},
+		{"3", 0, 3, "3", ""},
+		{"0.0100", 2, 1, "0.01", ""},
+		{"0.001", 2, 0, "", `abi: "0.001" has more than 2 decimals`},
+		{"1.-5", 2, 0, "", `abi: invalid fixed point number "1.-5"`},
+		{"abc", 2, 0, "", `abi: invalid fixed point number "abc"`},
+		{".", 2, 0, "", `abi: invalid fixed point number "."`},
+	} {
+		f, err := ParseFixed(test.input, test.decimals)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("%d: expected err %q, got %v", i, test.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%d: unexpected error: %v", i, err)
+			continue
+		}
+		if f.Value.Int64() != test.value || f.Decimals != test.decimals {
+			t.Errorf("%d: expected %d with %d decimals, got %d with %d", i, test.value, test.decimals, f.Value, f.Decimals)
+		}
+		if f.String() != test.str {
+			t.Errorf("%d: expected string %q, got %q", i, test.str, f.String())
+		}
+	}
+}
+
+func TestPackFixedErrors(t *testing.T) {
+	for i, test := range []struct {
+		typ   string
+		input *Fixed
+		err   string
+	}{
+		{"fixed128x1", NewFixed(big.NewInt(15), 2), "abi: 0.15 has more than 1 decimals"},
+		{"ufixed8x0", NewFixed(big.NewInt(256), 0), "abi: 256 overflows ufixed8x0"},
+		{"ufixed8x0", NewFixed(big.NewInt(-1), 0), "abi: -1 overflows ufixed8x0"},
+		{"fixed8x0", NewFixed(big.NewInt(128), 0), "abi: 128 overflows fixed8x0"},
+		{"fixed8x0", NewFixed(big.NewInt(-129), 0), "abi: -129 overflows fixed8x0"},
+	} {
+		typ, err := NewType(test.typ)
+		if err != nil {
+			t.Fatalf("%d: unexpected parse error: %v", i, err)
+		}
+		if _, err := typ.pack(reflect.ValueOf(test.input)); err == nil || err.Error() != test.err {
+			t.Errorf("%d: expected err %q, got %v", i, test.err, err)
+		}
+	}
+}
+
+func TestNewFunction(t *testing.T) {
+	addr := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	fn, err := NewFunction(addr, common.Hex2Bytes("a9059cbb"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	typ, _ := NewType("function")
+	packed, err := typ.pack(reflect.ValueOf(fn))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := common.Hex2Bytes("0000000000000000000000000000000000000001a9059cbb0000000000000000")
+	if !bytes.Equal(packed, want) {
+		t.Errorf("expected %x, got %x", want, packed)
+	}
+	if _, err := NewFunction(addr, []byte{1}); err == nil {
+		t.Error("expected error for short selector")
+	}
+}
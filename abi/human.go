@@ -0,0 +1,269 @@
+// Copyright 2015 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// HumanReadable returns a parsed ABI interface out of human-readable
+// fragments, one per line, in the syntax of solidity declarations:
+//
+//     function transfer(address to, uint256 amount) returns (bool)
+//     function balanceOf(address) view returns (uint256)
+//     event Transfer(address indexed from, address indexed to, uint256 value)
+//     error InsufficientBalance(uint256 available, uint256 required)
+//     constructor(string name) payable
+//     fallback() external payable
+//     receive() external payable
+//
+// The `function` keyword is optional, tuples are written either as
+// `tuple(uint256 a, address b)` or `(uint256 a, address b)`, parameter names
+// are optional and data locations are ignored. Empty lines and lines starting
+// with `//` are skipped.
+func HumanReadable(reader io.Reader) (ABI, error) {
+	var fields []abiFragment
+
+	scanner := bufio.NewScanner(reader)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "//") {
+			continue
+		}
+		field, err := parseFragment(text)
+		if err != nil {
+			return ABI{}, fmt.Errorf("abi: line %d: %v", line, err)
+		}
+		fields = append(fields, field)
+	}
+	if err := scanner.Err(); err != nil {
+		return ABI{}, err
+	}
+	// reuse the JSON decoding to build the abi out of the fragments
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return ABI{}, err
+	}
+	return JSON(bytes.NewReader(data))
+}
+
+// abiFragment is the JSON representation of a parsed human-readable fragment.
+type abiFragment struct {
+	Type            string               `json:"type"`
+	Name            string               `json:"name,omitempty"`
+	StateMutability string               `json:"stateMutability,omitempty"`
+	Anonymous       bool                 `json:"anonymous,omitempty"`
+	Inputs          []ArgumentMarshaling `json:"inputs"`
+	Outputs         []ArgumentMarshaling `json:"outputs,omitempty"`
+}
+
+// fragmentParser is a recursive descent parser of a single fragment.
+type fragmentParser struct {
+	text string
+	pos  int
+}
+
+func parseFragment(text string) (abiFragment, error) {
+	p := &fragmentParser{text: strings.TrimSuffix(text, ";")}
+
+	field := abiFragment{Type: "function"}
+	switch word := p.peekIdent(); word {
+	case "function", "event", "error":
+		field.Type = p.ident()
+		field.Name = p.ident()
+	case "constructor", "fallback", "receive":
+		field.Type = p.ident()
+	default:
+		field.Name = p.ident()
+	}
+	if field.Type != "constructor" && field.Type != "fallback" && field.Type != "receive" && field.Name == "" {
+		return abiFragment{}, fmt.Errorf("missing %s name in %q", field.Type, text)
+	}
+
+	inputs, err := p.params(field.Type == "event")
+	if err != nil {
+		return abiFragment{}, err
+	}
+	field.Inputs = inputs
+
+	// modifiers and return values
+	for {
+		p.skipSpace()
+		if p.pos == len(p.text) {
+			break
+		}
+		switch word := p.ident(); word {
+		case "view", "pure", "payable", "nonpayable":
+			field.StateMutability = word
+		case "constant":
+			field.StateMutability = "view"
+		case "anonymous":
+			field.Anonymous = true
+		case "returns":
+			if field.Outputs, err = p.params(false); err != nil {
+				return abiFragment{}, err
+			}
+		case "external", "public", "internal", "virtual", "override":
+		case "":
+			return abiFragment{}, fmt.Errorf("unexpected %q at %d in %q", p.text[p.pos], p.pos, text)
+		default:
+			return abiFragment{}, fmt.Errorf("unexpected %q in %q", word, text)
+		}
+	}
+	if field.Type == "function" && field.Outputs == nil {
+		field.Outputs = []ArgumentMarshaling{}
+	}
+	if field.Type == "receive" {
+		field.StateMutability = "payable"
+	}
+	return field, nil
+}
+
+// params parses a parenthesised, comma separated parameter list.
+func (p *fragmentParser) params(indexable bool) ([]ArgumentMarshaling, error) {
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+	args := []ArgumentMarshaling{}
+	if p.skipSpace(); p.peek() == ')' {
+		p.pos++
+		return args, nil
+	}
+	for {
+		arg, err := p.param(indexable)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+
+		p.skipSpace()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case ')':
+			p.pos++
+			return args, nil
+		default:
+			return nil, fmt.Errorf("expected ',' or ')' at %d in %q", p.pos, p.text)
+		}
+	}
+}
+
+// param parses a single parameter: its type, followed by the optional
+// `indexed` keyword, data location and name.
+func (p *fragmentParser) param(indexable bool) (ArgumentMarshaling, error) {
+	var arg ArgumentMarshaling
+
+	p.skipSpace()
+	if p.peek() == '(' || p.peekIdent() == "tuple" {
+		p.ident()
+		components, err := p.params(false)
+		if err != nil {
+			return arg, err
+		}
+		arg.Type, arg.Components = "tuple", components
+	} else {
+		arg.Type = canonicalType(p.ident())
+		if arg.Type == "" {
+			return arg, fmt.Errorf("missing type at %d in %q", p.pos, p.text)
+		}
+	}
+	for p.skipSpace(); p.peek() == '['; p.skipSpace() {
+		end := strings.IndexByte(p.text[p.pos:], ']')
+		if end < 0 {
+			return arg, fmt.Errorf("unterminated array type in %q", p.text)
+		}
+		arg.Type += p.text[p.pos : p.pos+end+1]
+		p.pos += end + 1
+	}
+
+	for word := p.peekIdent(); word != ""; word = p.peekIdent() {
+		p.ident()
+		switch word {
+		case "indexed":
+			if !indexable {
+				return arg, fmt.Errorf("only event parameters can be indexed in %q", p.text)
+			}
+			arg.Indexed = true
+		case "memory", "calldata", "storage", "payable":
+		default:
+			if arg.Name != "" {
+				return arg, fmt.Errorf("unexpected %q in %q", word, p.text)
+			}
+			arg.Name = word
+		}
+	}
+	return arg, nil
+}
+
+// canonicalType expands the aliases of the elementary types.
+func canonicalType(typ string) string {
+	switch typ {
+	case "uint", "int":
+		return typ + "256"
+	case "byte":
+		return "bytes1"
+	}
+	return typ
+}
+
+func (p *fragmentParser) skipSpace() {
+	for p.pos < len(p.text) && (p.text[p.pos] == ' ' || p.text[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+func (p *fragmentParser) peek() byte {
+	if p.pos < len(p.text) {
+		return p.text[p.pos]
+	}
+	return 0
+}
+
+func (p *fragmentParser) expect(c byte) error {
+	if p.skipSpace(); p.peek() != c {
+		return fmt.Errorf("expected '%c' at %d in %q", c, p.pos, p.text)
+	}
+	p.pos++
+	return nil
+}
+
+// ident reads the next identifier, or returns an empty string if there is
+// none.
+func (p *fragmentParser) ident() string {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.text) && isIdentChar(p.text[p.pos]) {
+		p.pos++
+	}
+	return p.text[start:p.pos]
+}
+
+func (p *fragmentParser) peekIdent() string {
+	pos := p.pos
+	defer func() { p.pos = pos }()
+	return p.ident()
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || c == '$' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
@@ -32,11 +32,35 @@ import (
 // network. A method such as `Transact` does require a Tx and thus will
 // be flagged `true`.
 // Input specifies the required input parameters for this gives method.
+//
+// Name is the key of the method in ABI.Methods. Overloaded methods share
+// the same RawName, the name used in the contract, but get unique keys by
+// appending an index to the name of every overload after the first one
+// (e.g. `transfer`, `transfer0`, `transfer1`).
+//
+// StateMutability is one of `pure`, `view`, `nonpayable` or `payable`. It is
+// taken from the abi when present and derived from the legacy `constant` and
+// `payable` flags otherwise, so Const and Payable are always consistent with it.
 type Method struct {
-	Name    string
-	Const   bool
-	Inputs  []Argument
-	Outputs []Argument
+	Name            string
+	RawName         string
+	Const           bool
+	Inputs          []Argument
+	Outputs         []Argument
+	StateMutability string
+	Payable         bool
+}
+
+// IsConstant reports whether the method can't modify the contract state
+// (`view`, `pure` or a legacy `constant` method) and can therefore be
+// executed with a call instead of a transaction.
+func (method Method) IsConstant() bool {
+	return method.Const || method.StateMutability == "view" || method.StateMutability == "pure"
+}
+
+// IsPayable reports whether the method accepts ether along with the call.
+func (method Method) IsPayable() bool {
+	return method.Payable || method.StateMutability == "payable"
 }
 
 func (method Method) pack(args ...interface{}) ([]byte, error) {
@@ -44,36 +68,18 @@ func (method Method) pack(args ...interface{}) ([]byte, error) {
 	if len(args) != len(method.Inputs) {
 		return nil, fmt.Errorf("argument count mismatch: %d for %d", len(args), len(method.Inputs))
 	}
-	// variable input is the output appended at the end of packed
-	// output. This is used for strings and bytes types input.
-	var variableInput []byte
-
-	var ret []byte
+	types := make([]Type, len(args))
+	values := make([]reflect.Value, len(args))
 	for i, a := range args {
-		input := method.Inputs[i]
-		// pack the input
-		packed, err := input.Type.pack(reflect.ValueOf(a))
-		if err != nil {
-			return nil, fmt.Errorf("`%s` %v", method.Name, err)
-		}
-
-		// check for a slice type (string, bytes, slice)
-		if input.Type.requiresLengthPrefix() {
-			// calculate the offset
-			offset := len(method.Inputs)*32 + len(variableInput)
-			// set the offset
-			ret = append(ret, packNum(reflect.ValueOf(offset))...)
-			// Append the packed output to the variable input. The variable input
-			// will be appended at the end of the input.
-			variableInput = append(variableInput, packed...)
-		} else {
-			// append the packed value to the input
-			ret = append(ret, packed...)
-		}
+		types[i], values[i] = method.Inputs[i].Type, reflect.ValueOf(a)
+	}
+	// the arguments are encoded like a tuple: dynamic ones (string, bytes,
+	// slices and anything containing them) are appended after the head and
+	// referenced by their offset
+	ret, err := packSequence(types, values)
+	if err != nil {
+		return nil, fmt.Errorf("`%s` %v", method.Name, err)
 	}
-	// append the variable input at the end of the packed input
-	ret = append(ret, variableInput...)
-
 	return ret, nil
 }
 
@@ -92,28 +98,30 @@ func (method Method) tupleUnpack(v interface{}, output []byte) error {
 		typ   = value.Type()
 	)
 
-	j := 0
+	var fields map[int]int
+	if value.Kind() == reflect.Struct {
+		var err error
+		if fields, err = structFieldMap(method.Outputs, typ); err != nil {
+			return err
+		}
+	}
+
+	offset := 0
 	for i := 0; i < len(method.Outputs); i++ {
 		toUnpack := method.Outputs[i]
-		if toUnpack.Type.T == ArrayTy {
-			// need to move this up because they read sequentially
-			j += toUnpack.Type.Size
-		}
-		marshalledValue, err := toGoType((i+j)*32, toUnpack.Type, output)
+		marshalledValue, err := toGoType(offset, toUnpack.Type, output)
 		if err != nil {
 			return err
 		}
+		// static arrays and tuples are laid out in place, spanning several words
+		offset += getTypeSize(toUnpack.Type)
 		reflectValue := reflect.ValueOf(marshalledValue)
 
 		switch value.Kind() {
 		case reflect.Struct:
-			for j := 0; j < typ.NumField(); j++ {
-				field := typ.Field(j)
-				// TODO read tags: `abi:"fieldName"`
-				if field.Name == strings.ToUpper(method.Outputs[i].Name[:1])+method.Outputs[i].Name[1:] {
-					if err := set(value.Field(j), reflectValue, method.Outputs[i]); err != nil {
-						return err
-					}
+			if j, ok := fields[i]; ok {
+				if err := set(value.Field(j), reflectValue, method.Outputs[i]); err != nil {
+					return err
 				}
 			}
 		case reflect.Slice, reflect.Array:
@@ -181,7 +189,15 @@ func (m Method) Sig() string {
 		types[i] = input.Type.String()
 		i++
 	}
-	return fmt.Sprintf("%v(%v)", m.Name, strings.Join(types, ","))
+	return fmt.Sprintf("%v(%v)", m.rawName(), strings.Join(types, ","))
+}
+
+// rawName returns the name of the method as declared in the contract.
+func (m Method) rawName() string {
+	if m.RawName != "" {
+		return m.RawName
+	}
+	return m.Name
 }
 
 func (m Method) String() string {
@@ -196,11 +212,13 @@ func (m Method) String() string {
 		}
 		outputs[i] += output.Type.String()
 	}
-	constant := ""
-	if m.Const {
-		constant = "constant "
+	mutability := ""
+	if m.StateMutability != "" && m.StateMutability != "nonpayable" {
+		mutability = m.StateMutability + " "
+	} else if m.Const {
+		mutability = "constant "
 	}
-	return fmt.Sprintf("function %v(%v) %sreturns(%v)", m.Name, strings.Join(inputs, ", "), constant, strings.Join(outputs, ", "))
+	return fmt.Sprintf("function %v(%v) %sreturns(%v)", m.rawName(), strings.Join(inputs, ", "), mutability, strings.Join(outputs, ", "))
 }
 
 func (m Method) Id() []byte {
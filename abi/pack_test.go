@@ -319,6 +319,26 @@ func TestPack(t *testing.T) {
 			"foobar",
 			common.Hex2Bytes("0000000000000000000000000000000000000000000000000000000000000006666f6f6261720000000000000000000000000000000000000000000000000000"),
 		},
+		{
+			"ufixed128x2",
+			NewFixed(big.NewInt(150), 2),
+			common.Hex2Bytes("0000000000000000000000000000000000000000000000000000000000000096"),
+		},
+		{
+			"ufixed128x4",
+			NewFixed(big.NewInt(150), 2),
+			common.Hex2Bytes("0000000000000000000000000000000000000000000000000000000000003a98"),
+		},
+		{
+			"fixed128x2",
+			NewFixed(big.NewInt(-150), 2),
+			common.Hex2Bytes("ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff6a"),
+		},
+		{
+			"fixed[]",
+			[]*Fixed{NewFixed(big.NewInt(1), 0)},
+			common.Hex2Bytes("00000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000de0b6b3a7640000"),
+		},
 	} {
 		typ, err := NewType(test.typ)
 		if err != nil {
@@ -436,3 +456,180 @@ func TestPackNumber(t *testing.T) {
 		}
 	}
 }
+
+func TestPackTuple(t *testing.T) {
+	const definition = `[
+	{ "type" : "function", "name" : "static", "inputs" : [ { "name" : "s", "type" : "tuple", "components" : [ { "name" : "a", "type" : "uint256" }, { "name" : "b", "type" : "address" } ] }, { "name" : "c", "type" : "uint256" } ] },
+	{ "type" : "function", "name" : "dynamic", "inputs" : [ { "name" : "s", "type" : "tuple", "components" : [ { "name" : "a", "type" : "uint256" }, { "name" : "b", "type" : "string" } ] }, { "name" : "c", "type" : "uint256" } ] },
+	{ "type" : "function", "name" : "slice", "inputs" : [ { "name" : "s", "type" : "tuple[]", "components" : [ { "name" : "a", "type" : "uint256" }, { "name" : "b", "type" : "address" } ] } ] }
+]`
+	abi, err := JSON(strings.NewReader(definition))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sig := abi.Methods["static"].Sig(); sig != "static((uint256,address),uint256)" {
+		t.Errorf("static signature mismatch: have %s", sig)
+	}
+	if sig := abi.Methods["slice"].Sig(); sig != "slice((uint256,address)[])" {
+		t.Errorf("slice signature mismatch: have %s", sig)
+	}
+
+	type staticTuple struct {
+		A *big.Int
+		B common.Address
+	}
+	addr := common.Address{1}
+
+	// static tuples are laid out in place
+	sig := abi.Methods["static"].Id()
+	sig = append(sig, common.LeftPadBytes([]byte{1}, 32)...)
+	sig = append(sig, common.LeftPadBytes(addr[:], 32)...)
+	sig = append(sig, common.LeftPadBytes([]byte{2}, 32)...)
+
+	packed, err := abi.Pack("static", staticTuple{big.NewInt(1), addr}, big.NewInt(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(packed, sig) {
+		t.Errorf("expected %x got %x", sig, packed)
+	}
+
+	// dynamic tuples are referenced by an offset, their fields relative to the tuple
+	sig = abi.Methods["dynamic"].Id()
+	sig = append(sig, common.LeftPadBytes([]byte{0x40}, 32)...)
+	sig = append(sig, common.LeftPadBytes([]byte{2}, 32)...)
+	sig = append(sig, common.LeftPadBytes([]byte{1}, 32)...)
+	sig = append(sig, common.LeftPadBytes([]byte{0x40}, 32)...)
+	sig = append(sig, common.LeftPadBytes([]byte{2}, 32)...)
+	sig = append(sig, common.RightPadBytes([]byte("hi"), 32)...)
+
+	packed, err = abi.Pack("dynamic", struct {
+		A *big.Int
+		B string
+	}{big.NewInt(1), "hi"}, big.NewInt(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(packed, sig) {
+		t.Errorf("expected %x got %x", sig, packed)
+	}
+
+	// slices of static tuples are length prefixed and laid out in sequence
+	sig = abi.Methods["slice"].Id()
+	sig = append(sig, common.LeftPadBytes([]byte{0x20}, 32)...)
+	sig = append(sig, common.LeftPadBytes([]byte{2}, 32)...)
+	sig = append(sig, common.LeftPadBytes([]byte{1}, 32)...)
+	sig = append(sig, common.LeftPadBytes(addr[:], 32)...)
+	sig = append(sig, common.LeftPadBytes([]byte{2}, 32)...)
+	sig = append(sig, common.LeftPadBytes(addr[:], 32)...)
+
+	packed, err = abi.Pack("slice", []staticTuple{{big.NewInt(1), addr}, {big.NewInt(2), addr}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(packed, sig) {
+		t.Errorf("expected %x got %x", sig, packed)
+	}
+
+	if _, err := abi.Pack("static", struct{ A *big.Int }{big.NewInt(1)}, big.NewInt(2)); err == nil {
+		t.Error("expected error for missing tuple field")
+	}
+}
+
+// Tests the head/tail encoding of nested dynamic types against the examples
+// of the Solidity abi specification.
+func TestPackSpecVectors(t *testing.T) {
+	const definition = `[
+	{ "type" : "function", "name" : "f", "inputs" : [ { "type" : "uint256" }, { "type" : "uint32[]" }, { "type" : "bytes10" }, { "type" : "bytes" } ] },
+	{ "type" : "function", "name" : "g", "inputs" : [ { "type" : "uint256[][]" }, { "type" : "string[]" } ] },
+	{ "type" : "function", "name" : "sam", "inputs" : [ { "type" : "bytes" }, { "type" : "bool" }, { "type" : "uint256[]" } ] },
+	{ "type" : "function", "name" : "fixed", "inputs" : [ { "type" : "string[2]" }, { "type" : "uint256" } ] }
+]`
+	abi, err := JSON(strings.NewReader(definition))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var bytes10 [10]byte
+	copy(bytes10[:], "1234567890")
+
+	for i, test := range []struct {
+		method string
+		args   []interface{}
+		want   string
+	}{
+		{
+			"f",
+			[]interface{}{big.NewInt(0x123), []uint32{0x456, 0x789}, bytes10, []byte("Hello, world!")},
+			"8be65246" +
+				"0000000000000000000000000000000000000000000000000000000000000123" +
+				"0000000000000000000000000000000000000000000000000000000000000080" +
+				"3132333435363738393000000000000000000000000000000000000000000000" +
+				"00000000000000000000000000000000000000000000000000000000000000e0" +
+				"0000000000000000000000000000000000000000000000000000000000000002" +
+				"0000000000000000000000000000000000000000000000000000000000000456" +
+				"0000000000000000000000000000000000000000000000000000000000000789" +
+				"000000000000000000000000000000000000000000000000000000000000000d" +
+				"48656c6c6f2c20776f726c642100000000000000000000000000000000000000",
+		},
+		{
+			"g",
+			[]interface{}{[][]*big.Int{{big.NewInt(1), big.NewInt(2)}, {big.NewInt(3)}}, []string{"one", "two", "three"}},
+			"2289b18c" +
+				"0000000000000000000000000000000000000000000000000000000000000040" +
+				"0000000000000000000000000000000000000000000000000000000000000140" +
+				"0000000000000000000000000000000000000000000000000000000000000002" +
+				"0000000000000000000000000000000000000000000000000000000000000040" +
+				"00000000000000000000000000000000000000000000000000000000000000a0" +
+				"0000000000000000000000000000000000000000000000000000000000000002" +
+				"0000000000000000000000000000000000000000000000000000000000000001" +
+				"0000000000000000000000000000000000000000000000000000000000000002" +
+				"0000000000000000000000000000000000000000000000000000000000000001" +
+				"0000000000000000000000000000000000000000000000000000000000000003" +
+				"0000000000000000000000000000000000000000000000000000000000000003" +
+				"0000000000000000000000000000000000000000000000000000000000000060" +
+				"00000000000000000000000000000000000000000000000000000000000000a0" +
+				"00000000000000000000000000000000000000000000000000000000000000e0" +
+				"0000000000000000000000000000000000000000000000000000000000000003" +
+				"6f6e650000000000000000000000000000000000000000000000000000000000" +
+				"0000000000000000000000000000000000000000000000000000000000000003" +
+				"74776f0000000000000000000000000000000000000000000000000000000000" +
+				"0000000000000000000000000000000000000000000000000000000000000005" +
+				"7468726565000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			"sam",
+			[]interface{}{[]byte("dave"), true, []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)}},
+			"a5643bf2" +
+				"0000000000000000000000000000000000000000000000000000000000000060" +
+				"0000000000000000000000000000000000000000000000000000000000000001" +
+				"00000000000000000000000000000000000000000000000000000000000000a0" +
+				"0000000000000000000000000000000000000000000000000000000000000004" +
+				"6461766500000000000000000000000000000000000000000000000000000000" +
+				"0000000000000000000000000000000000000000000000000000000000000003" +
+				"0000000000000000000000000000000000000000000000000000000000000001" +
+				"0000000000000000000000000000000000000000000000000000000000000002" +
+				"0000000000000000000000000000000000000000000000000000000000000003",
+		},
+		{
+			"fixed",
+			[]interface{}{[2]string{"a", "b"}, big.NewInt(1)},
+			common.Bytes2Hex(abi.Methods["fixed"].Id()) +
+				"0000000000000000000000000000000000000000000000000000000000000040" +
+				"0000000000000000000000000000000000000000000000000000000000000001" +
+				"0000000000000000000000000000000000000000000000000000000000000040" +
+				"0000000000000000000000000000000000000000000000000000000000000080" +
+				"0000000000000000000000000000000000000000000000000000000000000001" +
+				"6100000000000000000000000000000000000000000000000000000000000000" +
+				"0000000000000000000000000000000000000000000000000000000000000001" +
+				"6200000000000000000000000000000000000000000000000000000000000000",
+		},
+	} {
+		packed, err := abi.Pack(test.method, test.args...)
+		if err != nil {
+			t.Fatalf("test %d (%s): pack failed: %v", i, test.method, err)
+		}
+		if want := common.Hex2Bytes(test.want); !bytes.Equal(packed, want) {
+			t.Errorf("test %d (%s): pack mismatch:\nhave %x\nwant %x", i, test.method, packed, want)
+		}
+	}
+}
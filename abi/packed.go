@@ -0,0 +1,136 @@
+// Copyright 2015 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// PackPacked packs the values using the non-standard packed mode of solidity,
+// i.e. the equivalent of abi.encodePacked(...): values take up only as many
+// bytes as their type, dynamic values are not length prefixed and elements of
+// arrays are padded to 32 bytes. Tuples and nested arrays are not supported,
+// the same as in solidity.
+func PackPacked(types []Type, values ...interface{}) ([]byte, error) {
+	if len(types) != len(values) {
+		return nil, fmt.Errorf("abi: argument count mismatch: %d for %d", len(values), len(types))
+	}
+	var packed []byte
+	for i, t := range types {
+		b, err := packPackedValue(t, reflect.ValueOf(values[i]))
+		if err != nil {
+			return nil, fmt.Errorf("abi: argument %d: %v", i, err)
+		}
+		packed = append(packed, b...)
+	}
+	return packed, nil
+}
+
+// SoliditySHA3 returns the keccak256 hash of the packed values, as calculated
+// by keccak256(abi.encodePacked(...)) in solidity.
+func SoliditySHA3(types []Type, values ...interface{}) (common.Hash, error) {
+	packed, err := PackPacked(types, values...)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash(packed), nil
+}
+
+// packPackedValue packs a single value of a PackPacked sequence.
+func packPackedValue(t Type, v reflect.Value) ([]byte, error) {
+	switch t.T {
+	case TupleTy:
+		return nil, fmt.Errorf("tuples can't be packed in packed mode")
+	case SliceTy, ArrayTy:
+		if t.Elem.T == SliceTy || t.Elem.T == ArrayTy || isDynamicType(*t.Elem) || t.Elem.T == TupleTy {
+			return nil, fmt.Errorf("%v can't be packed in packed mode", t)
+		}
+		v = indirect(v)
+		if err := typeCheck(t, v); err != nil {
+			return nil, err
+		}
+		// array elements keep their standard 32 byte encoding
+		var packed []byte
+		for i := 0; i < v.Len(); i++ {
+			b, err := t.Elem.pack(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			packed = append(packed, b...)
+		}
+		return packed, nil
+	case FixedPointTy:
+		b, err := t.pack(v)
+		if err != nil {
+			return nil, err
+		}
+		return b[32-t.Size/8:], nil
+	}
+
+	v = indirect(v)
+	if err := typeCheck(t, v); err != nil {
+		return nil, err
+	}
+	switch t.T {
+	case IntTy, UintTy:
+		return packPackedNum(t, v)
+	case BoolTy:
+		if v.Bool() {
+			return []byte{1}, nil
+		}
+		return []byte{0}, nil
+	case StringTy:
+		return []byte(v.String()), nil
+	case BytesTy, AddressTy, FixedBytesTy, FunctionTy:
+		if v.Kind() == reflect.Array {
+			v = mustArrayToByteSlice(v)
+		}
+		return common.CopyBytes(v.Bytes()), nil
+	}
+	return nil, fmt.Errorf("unsupported arg type: %v", t)
+}
+
+// packPackedNum packs an integer into the N/8 bytes of its intN or uintN type,
+// using two's complement for negative numbers.
+func packPackedNum(t Type, v reflect.Value) ([]byte, error) {
+	var n *big.Int
+	switch v.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n = new(big.Int).SetUint64(v.Uint())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n = big.NewInt(v.Int())
+	default:
+		n = v.Interface().(*big.Int)
+	}
+
+	var min, max *big.Int
+	if t.T == UintTy {
+		min, max = common.Big0, new(big.Int).Lsh(common.Big1, uint(t.Size))
+	} else {
+		max = new(big.Int).Lsh(common.Big1, uint(t.Size-1))
+		min = new(big.Int).Neg(max)
+	}
+	if n.Cmp(min) < 0 || n.Cmp(max) >= 0 {
+		return nil, fmt.Errorf("%v overflows %v", n, t)
+	}
+	return U256(new(big.Int).Set(n))[32-t.Size/8:], nil
+}
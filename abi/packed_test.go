@@ -0,0 +1,107 @@
+// Copyright 2015 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func mustTypes(t *testing.T, kinds ...string) []Type {
+	types := make([]Type, len(kinds))
+	for i, kind := range kinds {
+		typ, err := NewType(kind)
+		if err != nil {
+			t.Fatalf("invalid type %s: %v", kind, err)
+		}
+		types[i] = typ
+	}
+	return types
+}
+
+func TestPackPacked(t *testing.T) {
+	for i, test := range []struct {
+		types  []string
+		values []interface{}
+		output string
+	}{
+		// the example of the solidity documentation
+		{
+			[]string{"int16", "bytes1", "uint16", "string"},
+			[]interface{}{int16(-1), [1]byte{0x42}, uint16(3), "Hello, world!"},
+			"ffff42000348656c6c6f2c20776f726c6421",
+		},
+		{
+			[]string{"uint24", "int256", "bool", "bytes"},
+			[]interface{}{big.NewInt(0x0102), big.NewInt(-2), true, []byte{0xaa, 0xbb}},
+			"000102fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffe01aabb",
+		},
+		{
+			[]string{"address", "uint8[]"},
+			[]interface{}{common.HexToAddress("0x0000000000000000000000000000000000000001"), []uint8{1, 2}},
+			"0000000000000000000000000000000000000001" +
+				"0000000000000000000000000000000000000000000000000000000000000001" +
+				"0000000000000000000000000000000000000000000000000000000000000002",
+		},
+		{
+			[]string{"ufixed16x1", "address[1]"},
+			[]interface{}{NewFixed(big.NewInt(15), 1), [1]common.Address{{1}}},
+			"000f" + "0000000000000000000000000100000000000000000000000000000000000000",
+		},
+	} {
+		packed, err := PackPacked(mustTypes(t, test.types...), test.values...)
+		if err != nil {
+			t.Errorf("%d: unexpected error: %v", i, err)
+			continue
+		}
+		if want := common.Hex2Bytes(test.output); !bytes.Equal(packed, want) {
+			t.Errorf("%d: expected %x, got %x", i, want, packed)
+		}
+		hash, err := SoliditySHA3(mustTypes(t, test.types...), test.values...)
+		if err != nil {
+			t.Errorf("%d: unexpected hash error: %v", i, err)
+		}
+		if hash != crypto.Keccak256Hash(packed) {
+			t.Errorf("%d: hash mismatch, got %x", i, hash)
+		}
+	}
+}
+
+func TestPackPackedErrors(t *testing.T) {
+	for i, test := range []struct {
+		types  []string
+		values []interface{}
+		err    string
+	}{
+		{[]string{"uint8"}, nil, "abi: argument count mismatch: 0 for 1"},
+		{[]string{"uint24"}, []interface{}{big.NewInt(1 << 24)}, "abi: argument 0: 16777216 overflows uint24"},
+		{[]string{"int24"}, []interface{}{big.NewInt(-1<<23 - 1)}, "abi: argument 0: -8388609 overflows int24"},
+		{[]string{"uint256"}, []interface{}{big.NewInt(-1)}, "abi: argument 0: -1 overflows uint256"},
+		{[]string{"string[]"}, []interface{}{[]string{"a"}}, "abi: argument 0: string[] can't be packed in packed mode"},
+		{[]string{"uint8[][]"}, []interface{}{[][]uint8{{1}}}, "abi: argument 0: uint8[][] can't be packed in packed mode"},
+		{[]string{"uint8"}, []interface{}{"1"}, "abi: argument 0: abi: cannot use string as type uint8 as argument"},
+	} {
+		_, err := PackPacked(mustTypes(t, test.types...), test.values...)
+		if err == nil || err.Error() != test.err {
+			t.Errorf("%d: expected err %q, got %v", i, test.err, err)
+		}
+	}
+}
@@ -19,6 +19,7 @@ package abi
 import (
 	"fmt"
 	"reflect"
+	"strings"
 )
 
 // indirect recursively dereferences the value until it either gets the value
@@ -80,8 +81,145 @@ func set(dst, src reflect.Value, output Argument) error {
 		dst.Set(src)
 	case dstType.Kind() == reflect.Ptr:
 		return set(dst.Elem(), src, output)
+	case srcType.Kind() == reflect.Struct && dstType.Kind() == reflect.Struct:
+		return setStruct(dst, src, output)
+	case srcType.Kind() == reflect.Slice && dstType.Kind() == reflect.Slice:
+		return setSlice(dst, src, output)
+	case srcType.Kind() == reflect.Array && dstType.Kind() == reflect.Array && dst.Len() == src.Len():
+		for i := 0; i < src.Len(); i++ {
+			if err := set(dst.Index(i), src.Index(i), output); err != nil {
+				return err
+			}
+		}
 	default:
 		return fmt.Errorf("abi: cannot unmarshal %v in to %v", src.Type(), dst.Type())
 	}
 	return nil
 }
+
+// Convert assigns an unpacked value to the value out points to, converting
+// the anonymous structs of tuples (also inside arrays and slices) into the
+// structs out declares for them, the same way unpacking does.
+func Convert(out interface{}, value interface{}) error {
+	dst := reflect.ValueOf(out)
+	if dst.Kind() != reflect.Ptr || dst.IsNil() {
+		return fmt.Errorf("abi: Convert(non-pointer %T)", out)
+	}
+	if value == nil {
+		return fmt.Errorf("abi: cannot convert nil in to %v", dst.Elem().Type())
+	}
+	return set(dst.Elem(), reflect.ValueOf(value), Argument{})
+}
+
+// setStruct assigns the fields of an unpacked tuple to the fields of a caller
+// supplied struct, which are either tagged with the component name or named
+// like the tuple field.
+func setStruct(dst, src reflect.Value, output Argument) error {
+	tagged, err := taggedFields(dst.Type())
+	if err != nil {
+		return err
+	}
+	for i := 0; i < src.NumField(); i++ {
+		name := src.Type().Field(i).Name
+		field := dst.FieldByName(name)
+		if index, ok := tagged[src.Type().Field(i).Tag.Get("json")]; ok {
+			field = dst.Field(index)
+		}
+		if !field.IsValid() {
+			return fmt.Errorf("abi: field %s can't be found in the given value", name)
+		}
+		if err := set(field, src.Field(i), output); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setSlice assigns an unpacked slice element by element, allowing the
+// elements to be converted to the caller supplied element type.
+func setSlice(dst, src reflect.Value, output Argument) error {
+	slice := reflect.MakeSlice(dst.Type(), src.Len(), src.Len())
+	for i := 0; i < src.Len(); i++ {
+		if err := set(slice.Index(i), src.Index(i), output); err != nil {
+			return err
+		}
+	}
+	dst.Set(slice)
+	return nil
+}
+
+// taggedFields maps the `abi:"name"` tags of a struct to the index of the
+// field they are set on.
+func taggedFields(typ reflect.Type) (map[string]int, error) {
+	tagged := make(map[string]int)
+	for i := 0; i < typ.NumField(); i++ {
+		tag := typ.Field(i).Tag.Get("abi")
+		if tag == "" {
+			continue
+		}
+		if prev, ok := tagged[tag]; ok {
+			return nil, fmt.Errorf("abi: tag %q is set on both %s and %s", tag, typ.Field(prev).Name, typ.Field(i).Name)
+		}
+		tagged[tag] = i
+	}
+	return tagged, nil
+}
+
+// structFieldMap resolves the struct field each argument unpacks into,
+// mapping argument indices to field indices. A field tagged `abi:"name"`
+// receives the argument called name, untagged fields receive the argument
+// whose camel-cased name matches the field name (both `to` and `_to` go to
+// To).
+//
+// It fails if an argument has no field, if a tag doesn't name any argument or
+// if several arguments map to the same field, e.g. `to` and `_to`, which needs
+// a tag to disambiguate. Purely underscored arguments, e.g. `_`, need a tag.
+func structFieldMap(args []Argument, typ reflect.Type) (map[int]int, error) {
+	tagged, err := taggedFields(typ)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[int]int)
+	owners := make(map[int]int) // field index -> argument index
+	for i, arg := range args {
+		index, ok := tagged[arg.Name]
+		if !ok {
+			name := ToCamelCase(arg.Name)
+			if name == "" {
+				if arg.Name == "" {
+					return nil, fmt.Errorf("abi: unnamed argument %d has no field in %v", i, typ)
+				}
+				return nil, fmt.Errorf("abi: argument %q has no field in %v, use an abi tag to name one", arg.Name, typ)
+			}
+			field, found := typ.FieldByName(name)
+			if !found || len(field.Index) != 1 || field.Tag.Get("abi") != "" {
+				return nil, fmt.Errorf("abi: argument %q has no field %s in %v", arg.Name, name, typ)
+			}
+			index = field.Index[0]
+		}
+		if prev, ok := owners[index]; ok {
+			return nil, fmt.Errorf("abi: arguments %q and %q both map to field %s, use an abi tag to tell them apart", args[prev].Name, arg.Name, typ.Field(index).Name)
+		}
+		owners[index] = i
+		fields[i] = index
+	}
+	for tag, index := range tagged {
+		if _, ok := owners[index]; !ok {
+			return nil, fmt.Errorf("abi: field %s is tagged %q, but there is no such argument", typ.Field(index).Name, tag)
+		}
+	}
+	return fields, nil
+}
+
+// ToCamelCase converts an under-score string to a camel-case string, so that
+// abi names such as "_to" or "token_id" map to the exported Go field names
+// "To" and "TokenId".
+func ToCamelCase(input string) string {
+	parts := strings.Split(input, "_")
+	for i, s := range parts {
+		if len(s) > 0 {
+			parts[i] = strings.ToUpper(s[:1]) + s[1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
@@ -0,0 +1,172 @@
+// Copyright 2015 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	// revertSelector is the selector of the Error(string) revert data
+	// emitted by require, revert and the compiler checks.
+	revertSelector = crypto.Keccak256([]byte("Error(string)"))[:4]
+	// panicSelector is the selector of the Panic(uint256) revert data
+	// emitted by failing asserts and arithmetic checks.
+	panicSelector = crypto.Keccak256([]byte("Panic(uint256)"))[:4]
+
+	errNoRevertReason = errors.New("abi: revert data doesn't carry a known reason")
+)
+
+// panicReasons describes the Panic(uint256) codes defined by solidity.
+var panicReasons = map[uint64]string{
+	0x00: "generic panic",
+	0x01: "assert(false)",
+	0x11: "arithmetic underflow or overflow",
+	0x12: "division or modulo by zero",
+	0x21: "enum overflow",
+	0x22: "invalid encoded storage byte array accessed",
+	0x31: "out-of-bounds array access; popping on an empty array",
+	0x32: "out-of-bounds access of an array or bytesN",
+	0x41: "out of memory",
+	0x51: "uninitialized function",
+}
+
+// Error is a custom error declared in the contract (`error Name(...)`). It
+// is raised with `revert Name(...)` and returned as the 4-byte selector of
+// its signature followed by its abi encoded inputs.
+type Error struct {
+	Name   string
+	Inputs []Argument
+}
+
+// Sig returns the string signature of the error, e.g.
+// `InsufficientBalance(uint256,uint256)`.
+func (e Error) Sig() string {
+	types := make([]string, len(e.Inputs))
+	for i, input := range e.Inputs {
+		types[i] = input.Type.String()
+	}
+	return fmt.Sprintf("%v(%v)", e.Name, strings.Join(types, ","))
+}
+
+// Id returns the selector identifying the error in revert data.
+func (e Error) Id() []byte {
+	return crypto.Keccak256([]byte(e.Sig()))[:4]
+}
+
+func (e Error) String() string {
+	inputs := make([]string, len(e.Inputs))
+	for i, input := range e.Inputs {
+		inputs[i] = fmt.Sprintf("%v %v", input.Type, input.Name)
+	}
+	return fmt.Sprintf("error %v(%v)", e.Name, strings.Join(inputs, ", "))
+}
+
+// Unpack decodes the inputs of the error from the given revert data,
+// selector included.
+func (e Error) Unpack(data []byte) ([]interface{}, error) {
+	if len(data) < 4 || !bytes.Equal(data[:4], e.Id()) {
+		return nil, fmt.Errorf("abi: revert data is not a %s error", e.Name)
+	}
+	return unpackArguments(e.Inputs, data[4:])
+}
+
+// UnpackRevert decodes the human readable reason of the standard
+// Error(string) and Panic(uint256) revert data.
+func UnpackRevert(data []byte) (string, error) {
+	if len(data) < 4 {
+		return "", errNoRevertReason
+	}
+	switch {
+	case bytes.Equal(data[:4], revertSelector):
+		reason, err := toGoType(0, mustNewType("string"), data[4:])
+		if err != nil {
+			return "", err
+		}
+		return reason.(string), nil
+
+	case bytes.Equal(data[:4], panicSelector):
+		code, err := toGoType(0, mustNewType("uint256"), data[4:])
+		if err != nil {
+			return "", err
+		}
+		pcode := code.(*big.Int)
+		if pcode.IsUint64() {
+			if reason, ok := panicReasons[pcode.Uint64()]; ok {
+				return fmt.Sprintf("panic: %s (0x%x)", reason, pcode), nil
+			}
+		}
+		return fmt.Sprintf("panic: unknown code 0x%x", pcode), nil
+	}
+	return "", errNoRevertReason
+}
+
+// ErrorById looks up a custom error by the 4-byte selector at the beginning
+// of the given revert data.
+func (abi ABI) ErrorById(data []byte) (Error, error) {
+	if len(data) < 4 {
+		return Error{}, fmt.Errorf("data too short (%d bytes) for abi error lookup", len(data))
+	}
+	for _, e := range abi.Errors {
+		if bytes.Equal(e.Id(), data[:4]) {
+			return e, nil
+		}
+	}
+	return Error{}, fmt.Errorf("no error with id: %#x", data[:4])
+}
+
+// RevertReason decodes the human readable reason of the given revert data.
+// Besides the standard Error(string) and Panic(uint256) data, the custom
+// errors declared in the abi are recognised and formatted along with their
+// inputs, e.g. `InsufficientBalance(available: 1, required: 2)`.
+func (abi ABI) RevertReason(data []byte) (string, error) {
+	if reason, err := UnpackRevert(data); err != errNoRevertReason {
+		return reason, err
+	}
+	e, err := abi.ErrorById(data)
+	if err != nil {
+		return "", errNoRevertReason
+	}
+	values, err := e.Unpack(data)
+	if err != nil {
+		return "", err
+	}
+	args := make([]string, len(values))
+	for i, value := range values {
+		if name := e.Inputs[i].Name; name != "" {
+			args[i] = fmt.Sprintf("%s: %v", name, value)
+		} else {
+			args[i] = fmt.Sprintf("%v", value)
+		}
+	}
+	return fmt.Sprintf("%s(%s)", e.Name, strings.Join(args, ", ")), nil
+}
+
+// mustNewType creates a type out of a known good type name.
+func mustNewType(t string) Type {
+	typ, err := NewType(t)
+	if err != nil {
+		panic(err)
+	}
+	return typ
+}
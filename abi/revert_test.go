@@ -0,0 +1,126 @@
+// Copyright 2016 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestUnpackRevert(t *testing.T) {
+	for i, test := range []struct {
+		data string
+		want string
+		err  bool
+	}{
+		// require(false, "revert reason")
+		{
+			"08c379a0" +
+				"0000000000000000000000000000000000000000000000000000000000000020" +
+				"000000000000000000000000000000000000000000000000000000000000000d" +
+				"72657665727420726561736f6e00000000000000000000000000000000000000",
+			"revert reason", false,
+		},
+		// assert(false)
+		{
+			"4e487b71" +
+				"0000000000000000000000000000000000000000000000000000000000000001",
+			"panic: assert(false) (0x1)", false,
+		},
+		// arithmetic overflow
+		{
+			"4e487b71" +
+				"0000000000000000000000000000000000000000000000000000000000000011",
+			"panic: arithmetic underflow or overflow (0x11)", false,
+		},
+		{
+			"4e487b71" +
+				"00000000000000000000000000000000000000000000000000000000000000ff",
+			"panic: unknown code 0xff", false,
+		},
+		// truncated reason
+		{"08c379a0" + "0000000000000000000000000000000000000000000000000000000000000020", "", true},
+		{"deadbeef", "", true},
+		{"", "", true},
+	} {
+		reason, err := UnpackRevert(common.Hex2Bytes(test.data))
+		if test.err {
+			if err == nil {
+				t.Errorf("test %d: expected error, got %q", i, reason)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("test %d: unexpected error: %v", i, err)
+			continue
+		}
+		if reason != test.want {
+			t.Errorf("test %d: reason mismatch: have %q, want %q", i, reason, test.want)
+		}
+	}
+}
+
+func TestCustomErrorRevertReason(t *testing.T) {
+	const definition = `[
+	{ "type" : "error", "name" : "InsufficientBalance", "inputs" : [ { "name" : "available", "type" : "uint256" }, { "name" : "required", "type" : "uint256" } ] },
+	{ "type" : "error", "name" : "Unauthorized", "inputs" : [] }
+]`
+	abi, err := JSON(strings.NewReader(definition))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(abi.Errors) != 2 {
+		t.Fatalf("expected 2 errors, got %d", len(abi.Errors))
+	}
+	if sig := abi.Errors["InsufficientBalance"].Sig(); sig != "InsufficientBalance(uint256,uint256)" {
+		t.Errorf("signature mismatch: %s", sig)
+	}
+
+	data := append(crypto.Keccak256([]byte("InsufficientBalance(uint256,uint256)"))[:4], common.Hex2Bytes(
+		"000000000000000000000000000000000000000000000000000000000000000a"+
+			"0000000000000000000000000000000000000000000000000000000000000014")...)
+	reason, err := abi.RevertReason(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "InsufficientBalance(available: 10, required: 20)"; reason != want {
+		t.Errorf("reason mismatch: have %q, want %q", reason, want)
+	}
+
+	reason, err = abi.RevertReason(crypto.Keccak256([]byte("Unauthorized()"))[:4])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Unauthorized()"; reason != want {
+		t.Errorf("reason mismatch: have %q, want %q", reason, want)
+	}
+
+	// the standard reasons are still decoded
+	reason, err = abi.RevertReason(common.Hex2Bytes("4e487b71" + "0000000000000000000000000000000000000000000000000000000000000012"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "panic: division or modulo by zero (0x12)"; reason != want {
+		t.Errorf("reason mismatch: have %q, want %q", reason, want)
+	}
+	if _, err := abi.RevertReason(common.Hex2Bytes("deadbeef")); err == nil {
+		t.Error("expected error for unknown selector")
+	}
+}
@@ -17,6 +17,7 @@
 package abi
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"regexp"
@@ -37,6 +38,7 @@ const (
 	HashTy
 	FixedPointTy
 	FunctionTy
+	TupleTy
 )
 
 // Type is the reflection of the supported argument type
@@ -48,6 +50,11 @@ type Type struct {
 	Size int
 	T    byte // Our own type checking
 
+	Decimals int // Number of decimals (N) of fixedMxN and ufixedMxN types
+
+	TupleElems    []*Type  // Type information of all tuple fields
+	TupleRawNames []string // Raw field names of all tuple fields, as found in the abi
+
 	stringKind string // holds the unparsed string for deriving signatures
 }
 
@@ -56,8 +63,10 @@ var (
 	typeRegex = regexp.MustCompile("([a-zA-Z]+)(([0-9]+)(x([0-9]+))?)?")
 )
 
-// NewType creates a new reflection type of abi type given in t.
-func NewType(t string) (typ Type, err error) {
+// NewType creates a new reflection type of abi type given in t. Tuple types
+// (and arrays of tuples) additionally require the components describing
+// their fields.
+func NewType(t string, components ...ArgumentMarshaling) (typ Type, err error) {
 	// check that array brackets are equal if they exist
 	if strings.Count(t, "[") != strings.Count(t, "]") {
 		return Type{}, fmt.Errorf("invalid arg type in abi")
@@ -70,12 +79,15 @@ func NewType(t string) (typ Type, err error) {
 	if strings.Count(t, "[") != 0 {
 		i := strings.LastIndex(t, "[")
 		// recursively embed the type
-		embeddedType, err := NewType(t[:i])
+		embeddedType, err := NewType(t[:i], components...)
 		if err != nil {
 			return Type{}, err
 		}
 		// grab the last cell and create a type from there
 		sliced := t[i:]
+		// tuples are named by their canonical form, so derive the
+		// signature from the embedded type rather than the raw string
+		typ.stringKind = embeddedType.stringKind + sliced
 		// grab the slice size with regexp
 		re := regexp.MustCompile("[0-9]+")
 		intz := re.FindAllString(sliced, -1)
@@ -107,7 +119,7 @@ func NewType(t string) (typ Type, err error) {
 		var varSize int
 		if len(parsedType[3]) > 0 {
 			var err error
-			varSize, err = strconv.Atoi(parsedType[2])
+			varSize, err = strconv.Atoi(parsedType[3])
 			if err != nil {
 				return Type{}, fmt.Errorf("abi: error parsing variable size: %v", err)
 			}
@@ -154,11 +166,31 @@ func NewType(t string) (typ Type, err error) {
 				typ.Size = varSize
 				typ.Type = reflect.ArrayOf(varSize, reflect.TypeOf(byte(0)))
 			}
+		case "fixed", "ufixed":
+			// a bare fixed or ufixed is an alias of the 128x18 variant
+			if len(parsedType[3]) == 0 {
+				varSize = 128
+				typ.Decimals = 18
+				typ.stringKind = fmt.Sprintf("%s%dx%d", varType, varSize, typ.Decimals)
+			} else if len(parsedType[5]) == 0 {
+				return Type{}, fmt.Errorf("unsupported arg type: %s", t)
+			} else if typ.Decimals, err = strconv.Atoi(parsedType[5]); err != nil {
+				return Type{}, fmt.Errorf("abi: error parsing variable size: %v", err)
+			}
+			if varSize < 8 || varSize > 256 || varSize%8 != 0 || typ.Decimals > 80 {
+				return Type{}, fmt.Errorf("unsupported arg type: %s", t)
+			}
+			typ.Kind = reflect.Ptr
+			typ.Type = fixed_t
+			typ.Size = varSize
+			typ.T = FixedPointTy
 		case "function":
 			typ.Kind = reflect.Array
 			typ.T = FunctionTy
 			typ.Size = 24
 			typ.Type = reflect.ArrayOf(24, reflect.TypeOf(byte(0)))
+		case "tuple":
+			return newTupleType(components)
 		default:
 			return Type{}, fmt.Errorf("unsupported arg type: %s", t)
 		}
@@ -167,12 +199,65 @@ func NewType(t string) (typ Type, err error) {
 	return
 }
 
+// newTupleType creates the reflection type of a tuple out of its components.
+// The resulting Go type is an anonymous struct holding one exported field per
+// component, named after the camel cased component name.
+func newTupleType(components []ArgumentMarshaling) (Type, error) {
+	if len(components) == 0 {
+		return Type{}, errors.New("abi: tuple type without components")
+	}
+	var (
+		typ    Type
+		fields []reflect.StructField
+		elems  []*Type
+		names  []string
+		kinds  []string
+		used   = make(map[string]bool)
+	)
+	for i, c := range components {
+		cType, err := NewType(c.Type, c.Components...)
+		if err != nil {
+			return Type{}, err
+		}
+		name := ToCamelCase(c.Name)
+		if name == "" {
+			name = fmt.Sprintf("Field%d", i)
+		}
+		if used[name] {
+			return Type{}, fmt.Errorf("abi: duplicate tuple field name %q", name)
+		}
+		used[name] = true
+
+		fields = append(fields, reflect.StructField{
+			Name: name,
+			Type: cType.Type,
+			Tag:  reflect.StructTag(`json:"` + c.Name + `"`),
+		})
+		elems = append(elems, &cType)
+		names = append(names, c.Name)
+		kinds = append(kinds, cType.String())
+	}
+	typ.Kind = reflect.Struct
+	typ.Type = reflect.StructOf(fields)
+	typ.T = TupleTy
+	typ.TupleElems = elems
+	typ.TupleRawNames = names
+	typ.stringKind = "(" + strings.Join(kinds, ",") + ")"
+
+	return typ, nil
+}
+
 // String implements Stringer
 func (t Type) String() (out string) {
 	return t.stringKind
 }
 
 func (t Type) pack(v reflect.Value) ([]byte, error) {
+	// fixed point numbers are passed as *Fixed, so they are packed before
+	// any dereferencing takes place
+	if t.T == FixedPointTy {
+		return packFixed(t, v)
+	}
 	// dereference pointer first if it's a pointer
 	v = indirect(v)
 
@@ -180,27 +265,89 @@ func (t Type) pack(v reflect.Value) ([]byte, error) {
 		return nil, err
 	}
 
-	if t.T == SliceTy || t.T == ArrayTy {
-		var packed []byte
-
-		for i := 0; i < v.Len(); i++ {
-			val, err := t.Elem.pack(v.Index(i))
+	switch t.T {
+	case TupleTy:
+		types := make([]Type, len(t.TupleElems))
+		values := make([]reflect.Value, len(t.TupleElems))
+		for i, elem := range t.TupleElems {
+			field, err := tupleField(v, t.TupleRawNames[i], i)
 			if err != nil {
 				return nil, err
 			}
-			packed = append(packed, val...)
+			types[i], values[i] = *elem, field
+		}
+		return packSequence(types, values)
+
+	case SliceTy, ArrayTy:
+		types := make([]Type, v.Len())
+		values := make([]reflect.Value, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			types[i], values[i] = *t.Elem, v.Index(i)
+		}
+		packed, err := packSequence(types, values)
+		if err != nil {
+			return nil, err
 		}
+		// slices are additionally prefixed with their element count
 		if t.T == SliceTy {
-			return packBytesSlice(packed, v.Len()), nil
-		} else if t.T == ArrayTy {
-			return packed, nil
+			return append(packNum(reflect.ValueOf(v.Len())), packed...), nil
 		}
+		return packed, nil
 	}
 	return packElement(t, v), nil
 }
 
+// tupleField looks up the struct field holding the tuple component with the
+// given raw name, falling back to the positional field for unnamed components.
+func tupleField(v reflect.Value, name string, index int) (reflect.Value, error) {
+	fieldName := ToCamelCase(name)
+	if fieldName == "" {
+		fieldName = fmt.Sprintf("Field%d", index)
+	}
+	if field := v.FieldByName(fieldName); field.IsValid() {
+		return field, nil
+	}
+	return reflect.Value{}, fmt.Errorf("abi: field %s can't be found in the given value", fieldName)
+}
+
 // requireLengthPrefix returns whether the type requires any sort of length
 // prefixing.
 func (t Type) requiresLengthPrefix() bool {
 	return t.T == StringTy || t.T == BytesTy || t.T == SliceTy
 }
+
+// isDynamicType returns whether the encoding of the type is placed in the
+// tail of the enclosing tuple and referenced by an offset from its head.
+func isDynamicType(t Type) bool {
+	switch t.T {
+	case TupleTy:
+		for _, elem := range t.TupleElems {
+			if isDynamicType(*elem) {
+				return true
+			}
+		}
+		return false
+	case ArrayTy:
+		return isDynamicType(*t.Elem)
+	}
+	return t.requiresLengthPrefix()
+}
+
+// getTypeSize returns the number of bytes the type occupies in the head of
+// the enclosing tuple. Dynamic types only take up the 32 byte offset word.
+func getTypeSize(t Type) int {
+	if isDynamicType(t) {
+		return 32
+	}
+	switch t.T {
+	case ArrayTy:
+		return t.Size * getTypeSize(*t.Elem)
+	case TupleTy:
+		total := 0
+		for _, elem := range t.TupleElems {
+			total += getTypeSize(*elem)
+		}
+		return total
+	}
+	return 32
+}
@@ -87,13 +87,12 @@ func TestTypeRegexp(t *testing.T) {
 		{"address", Type{Kind: reflect.Array, Type: address_t, Size: 20, T: AddressTy, stringKind: "address"}},
 		{"address[]", Type{T: SliceTy, Kind: reflect.Slice, Type: reflect.TypeOf([]common.Address{}), Elem: &Type{Kind: reflect.Array, Type: address_t, Size: 20, T: AddressTy, stringKind: "address"}, stringKind: "address[]"}},
 		{"address[2]", Type{Kind: reflect.Array, T: ArrayTy, Size: 2, Type: reflect.TypeOf([2]common.Address{}), Elem: &Type{Kind: reflect.Array, Type: address_t, Size: 20, T: AddressTy, stringKind: "address"}, stringKind: "address[2]"}},
-		// TODO when fixed types are implemented properly
-		// {"fixed", Type{}},
-		// {"fixed128x128", Type{}},
-		// {"fixed[]", Type{}},
-		// {"fixed[2]", Type{}},
-		// {"fixed128x128[]", Type{}},
-		// {"fixed128x128[2]", Type{}},
+		{"fixed", Type{Kind: reflect.Ptr, Type: fixed_t, Size: 128, Decimals: 18, T: FixedPointTy, stringKind: "fixed128x18"}},
+		{"ufixed", Type{Kind: reflect.Ptr, Type: fixed_t, Size: 128, Decimals: 18, T: FixedPointTy, stringKind: "ufixed128x18"}},
+		{"fixed64x10", Type{Kind: reflect.Ptr, Type: fixed_t, Size: 64, Decimals: 10, T: FixedPointTy, stringKind: "fixed64x10"}},
+		{"fixed[]", Type{Kind: reflect.Slice, T: SliceTy, Type: reflect.TypeOf([]*Fixed{}), Elem: &Type{Kind: reflect.Ptr, Type: fixed_t, Size: 128, Decimals: 18, T: FixedPointTy, stringKind: "fixed128x18"}, stringKind: "fixed128x18[]"}},
+		{"ufixed256x80[2]", Type{Kind: reflect.Array, T: ArrayTy, Size: 2, Type: reflect.TypeOf([2]*Fixed{}), Elem: &Type{Kind: reflect.Ptr, Type: fixed_t, Size: 256, Decimals: 80, T: FixedPointTy, stringKind: "ufixed256x80"}, stringKind: "ufixed256x80[2]"}},
+		{"function", Type{Kind: reflect.Array, T: FunctionTy, Size: 24, Type: reflect.TypeOf([24]byte{}), stringKind: "function"}},
 	}
 
 	for _, tt := range tests {
@@ -249,6 +248,13 @@ func TestTypeCheck(t *testing.T) {
 		{"string", []byte{}, "abi: cannot use slice as type string as argument"},
 		{"bytes32[]", [][32]byte{{}}, ""},
 		{"function", [24]byte{}, ""},
+		{"function", [20]byte{}, "abi: cannot use [20]uint8 as type [24]uint8 as argument"},
+		{"fixed128x18", NewFixed(big.NewInt(1), 18), ""},
+		{"ufixed8x1", NewFixed(big.NewInt(1), 1), ""},
+		{"fixed128x18", big.NewInt(1), "abi: cannot use *big.Int as type *abi.Fixed as argument"},
+		{"fixed128", NewFixed(big.NewInt(1), 18), "unsupported arg type: fixed128"},
+		{"fixed12x18", NewFixed(big.NewInt(1), 18), "unsupported arg type: fixed12x18"},
+		{"fixed128x81", NewFixed(big.NewInt(1), 18), "unsupported arg type: fixed128x81"},
 		{"bytes20", common.Address{}, ""},
 		{"address", [20]byte{}, ""},
 		{"address", common.Address{}, ""},
@@ -102,15 +102,20 @@ func readFixedBytes(t Type, word []byte) (interface{}, error) {
 
 }
 
-// iteratively unpack elements
+// iteratively unpack elements laid out in sequence from start. Static
+// elements occupy their full size, dynamic ones a single offset word which is
+// relative to start.
 func forEachUnpack(t Type, output []byte, start, size int) (interface{}, error) {
-	if start+32*size > len(output) {
-		return nil, fmt.Errorf("abi: cannot marshal in to go array: offset %d would go over slice boundary (len=%d)", len(output), start+32*size)
+	if size < 0 {
+		return nil, fmt.Errorf("abi: cannot marshal in to go array: size %d is negative", size)
+	}
+	elemSize := getTypeSize(*t.Elem)
+	if start+elemSize*size > len(output) {
+		return nil, fmt.Errorf("abi: cannot marshal in to go array: offset %d would go over slice boundary (len=%d)", len(output), start+elemSize*size)
 	}
 
 	// this value will become our slice or our array, depending on the type
 	var refSlice reflect.Value
-	slice := output[start : start+size*32]
 
 	if t.T == SliceTy {
 		// declare our slice
@@ -122,12 +127,10 @@ func forEachUnpack(t Type, output []byte, start, size int) (interface{}, error)
 		return nil, fmt.Errorf("abi: invalid type in array/slice unpacking stage")
 	}
 
-	for i, j := start, 0; j*32 < len(slice); i, j = i+32, j+1 {
-		// this corrects the arrangement so that we get all the underlying array values
-		if t.Elem.T == ArrayTy && j != 0 {
-			i = start + t.Elem.Size*32*j
-		}
-		inter, err := toGoType(i, *t.Elem, output)
+	// dynamic elements point into the data following the sequence start
+	elems := output[start:]
+	for i, j := 0, 0; j < size; i, j = i+elemSize, j+1 {
+		inter, err := toGoType(i, *t.Elem, elems)
 		if err != nil {
 			return nil, err
 		}
@@ -139,6 +142,23 @@ func forEachUnpack(t Type, output []byte, start, size int) (interface{}, error)
 	return refSlice.Interface(), nil
 }
 
+// forTupleUnpack unpacks the fields of a tuple starting at the beginning of
+// output into the struct type derived for t.
+func forTupleUnpack(t Type, output []byte) (interface{}, error) {
+	retval := reflect.New(t.Type).Elem()
+
+	offset := 0
+	for i, elem := range t.TupleElems {
+		marshalledValue, err := toGoType(offset, *elem, output)
+		if err != nil {
+			return nil, err
+		}
+		retval.Field(i).Set(reflect.ValueOf(marshalledValue))
+		offset += getTypeSize(*elem)
+	}
+	return retval.Interface(), nil
+}
+
 // toGoType parses the output bytes and recursively assigns the value of these bytes
 // into a go type with accordance with the ABI spec.
 func toGoType(index int, t Type, output []byte) (interface{}, error) {
@@ -163,9 +183,27 @@ func toGoType(index int, t Type, output []byte) (interface{}, error) {
 	}
 
 	switch t.T {
+	case TupleTy:
+		// dynamic tuples are pointed to by an offset, static ones are in place
+		if isDynamicType(t) {
+			offset, err := offsetPointsTo(index, output)
+			if err != nil {
+				return nil, err
+			}
+			return forTupleUnpack(t, output[offset:])
+		}
+		return forTupleUnpack(t, output[index:])
 	case SliceTy:
 		return forEachUnpack(t, output, begin, end)
 	case ArrayTy:
+		// arrays of dynamic types are pointed to by an offset, others are in place
+		if isDynamicType(t) {
+			offset, err := offsetPointsTo(index, output)
+			if err != nil {
+				return nil, err
+			}
+			return forEachUnpack(t, output, offset, t.Size)
+		}
 		return forEachUnpack(t, output, index, t.Size)
 	case StringTy: // variable arrays are written at the end of the return bytes
 		return string(output[begin : begin+end]), nil
@@ -183,24 +221,42 @@ func toGoType(index int, t Type, output []byte) (interface{}, error) {
 		return readFixedBytes(t, returnOutput)
 	case FunctionTy:
 		return readFunctionType(t, returnOutput)
+	case FixedPointTy:
+		return readFixed(t, returnOutput)
 	default:
 		return nil, fmt.Errorf("abi: unknown type %v", t.T)
 	}
 }
 
+// offsetPointsTo interprets the 32 byte word at index as the offset of a
+// dynamic value and checks that it stays within the output.
+func offsetPointsTo(index int, output []byte) (int, error) {
+	offset := new(big.Int).SetBytes(output[index : index+32])
+	// compare without adding to the offset, which may be close to overflowing
+	if offset.Cmp(big.NewInt(int64(len(output)-32))) > 0 {
+		return 0, fmt.Errorf("abi: cannot marshal in to go type: offset %v would go over slice boundary (len=%d)", offset, len(output))
+	}
+	return int(offset.Int64()), nil
+}
+
 // interprets a 32 byte slice as an offset and then determines which indice to look to decode the type.
 func lengthPrefixPointsTo(index int, output []byte) (start int, length int, err error) {
-	offset := int(binary.BigEndian.Uint64(output[index+24 : index+32]))
-	if offset+32 > len(output) {
-		return 0, 0, fmt.Errorf("abi: cannot marshal in to go slice: offset %d would go over slice boundary (len=%d)", len(output), offset+32)
+	offset, err := offsetPointsTo(index, output)
+	if err != nil {
+		return 0, 0, err
 	}
-	length = int(binary.BigEndian.Uint64(output[offset+24 : offset+32]))
-	if offset+32+length > len(output) {
-		return 0, 0, fmt.Errorf("abi: cannot marshal in to go type: length insufficient %d require %d", len(output), offset+32+length)
+	bigLength := new(big.Int).SetBytes(output[offset : offset+32])
+	if !bigLength.IsInt64() || bigLength.Int64() > int64(len(output)) {
+		return 0, 0, fmt.Errorf("abi: cannot marshal in to go type: length %v exceeds output (len=%d)", bigLength, len(output))
 	}
+	length = int(bigLength.Int64())
 	start = offset + 32
 
-	//fmt.Printf("LENGTH PREFIX INFO: \nsize: %v\noffset: %v\nstart: %v\n", length, offset, start)
+	// the length of slices counts elements, which are checked when unpacking
+	// them; strings and bytes must fit the output as a whole
+	if start+length > len(output) {
+		return 0, 0, fmt.Errorf("abi: cannot marshal in to go type: length insufficient %d require %d", len(output), start+length)
+	}
 	return
 }
 
@@ -149,7 +149,7 @@ var unpackTests = []unpackTest{
 	// multi dimensional, if these pass, all types that don't require length prefix should pass
 	{
 		def:  `[{"type": "uint8[][]"}]`,
-		enc:  "00000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000008000000000000000000000000000000000000000000000000000000000000000E0000000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002",
+		enc:  "00000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000004000000000000000000000000000000000000000000000000000000000000000a0000000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002",
 		want: [][]uint8{{1, 2}, {1, 2}},
 	},
 	{
@@ -159,7 +159,7 @@ var unpackTests = []unpackTest{
 	},
 	{
 		def:  `[{"type": "uint8[][2]"}]`,
-		enc:  "000000000000000000000000000000000000000000000000000000000000004000000000000000000000000000000000000000000000000000000000000000800000000000000000000000000000000000000000000000000000000000000001000000000000000000000000000000000000000000000000000000000000000100000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000001",
+		enc:  "0000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000004000000000000000000000000000000000000000000000000000000000000000800000000000000000000000000000000000000000000000000000000000000001000000000000000000000000000000000000000000000000000000000000000100000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000001",
 		want: [2][]uint8{{1}, {1}},
 	},
 	{
@@ -257,6 +257,51 @@ var unpackTests = []unpackTest{
 		enc:  "000000000000000000000000000000000000000000000000000000000000000100000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000003",
 		want: [3]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)},
 	},
+	{
+		def:  `[{"type": "ufixed128x2"}]`,
+		enc:  "0000000000000000000000000000000000000000000000000000000000000096",
+		want: NewFixed(big.NewInt(150), 2),
+	},
+	{
+		def:  `[{"type": "fixed128x2"}]`,
+		enc:  "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff6a",
+		want: NewFixed(big.NewInt(-150), 2),
+	},
+	{
+		def:  `[{"type": "fixed8x1[2]"}]`,
+		enc:  "0000000000000000000000000000000000000000000000000000000000000001ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		want: [2]*Fixed{NewFixed(big.NewInt(1), 1), NewFixed(big.NewInt(-1), 1)},
+	},
+	{
+		def:  `[{"type": "function"}]`,
+		enc:  "0100000000000000000000000000000000000000a9059cbb0000000000000000",
+		want: [24]byte{1, 20: 0xa9, 0x05, 0x9c, 0xbb},
+	},
+	{
+		def:  `[{"type": "function"}]`,
+		enc:  "0100000000000000000000000000000000000000a9059cbb0000000000000001",
+		want: [24]byte{},
+		err:  "abi: got improperly encoded function type, got [1 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 169 5 156 187 0 0 0 0 0 0 0 1]",
+	},
+	// offsets close to overflowing once the length word is added
+	{
+		def:  `[{"type": "string"}]`,
+		enc:  "7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		want: "",
+		err:  "abi: cannot marshal in to go type: offset 57896044618658097711785492504343953926634992332820282019728792003956564819967 would go over slice boundary (len=32)",
+	},
+	{
+		def:  `[{"type": "string"}]`,
+		enc:  "0000000000000000000000000000000000000000000000007fffffffffffffff",
+		want: "",
+		err:  "abi: cannot marshal in to go type: offset 9223372036854775807 would go over slice boundary (len=32)",
+	},
+	{
+		def:  `[{"type": "bytes"}]`,
+		enc:  "0000000000000000000000000000000000000000000000007fffffffffffffe1",
+		want: []byte(nil),
+		err:  "abi: cannot marshal in to go type: offset 9223372036854775777 would go over slice boundary (len=32)",
+	},
 }
 
 func TestUnpack(t *testing.T) {
@@ -336,6 +381,85 @@ func TestMultiReturnWithStruct(t *testing.T) {
 	}
 }
 
+func TestUnpackWithAbiTags(t *testing.T) {
+	const definition = `[
+	{ "name" : "underscored", "outputs": [ { "name": "_to", "type": "address" }, { "name": "_value", "type": "uint256" } ] },
+	{ "name" : "ambiguous", "outputs": [ { "name": "to", "type": "address" }, { "name": "_to", "type": "uint256" } ] }]`
+
+	abi, err := JSON(strings.NewReader(definition))
+	if err != nil {
+		t.Fatal(err)
+	}
+	buff := new(bytes.Buffer)
+	buff.Write(common.Hex2Bytes("0000000000000000000000000100000000000000000000000000000000000000"))
+	buff.Write(common.Hex2Bytes("000000000000000000000000000000000000000000000000000000000000002a"))
+	to := common.HexToAddress("0x0100000000000000000000000000000000000000")
+
+	// underscored names map to their camel-cased field
+	var plain struct {
+		To    common.Address
+		Value *big.Int
+	}
+	if err := abi.Unpack(&plain, "underscored", buff.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if plain.To != to || plain.Value.Cmp(big.NewInt(42)) != 0 {
+		t.Errorf("unexpected values: %+v", plain)
+	}
+
+	// tags take precedence over field names
+	var tagged struct {
+		Recipient common.Address `abi:"_to"`
+		Amount    *big.Int       `abi:"_value"`
+		To        common.Address
+	}
+	if err := abi.Unpack(&tagged, "underscored", buff.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if tagged.Recipient != to || tagged.Amount.Cmp(big.NewInt(42)) != 0 || tagged.To != (common.Address{}) {
+		t.Errorf("unexpected values: %+v", tagged)
+	}
+
+	// `to` and `_to` both map to To unless tagged
+	var ambiguous struct {
+		To common.Address
+	}
+	if err := abi.Unpack(&ambiguous, "ambiguous", buff.Bytes()); err == nil {
+		t.Error("expected error for ambiguous fields")
+	}
+	var disambiguated struct {
+		To    common.Address
+		Value *big.Int `abi:"_to"`
+	}
+	if err := abi.Unpack(&disambiguated, "ambiguous", buff.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if disambiguated.To != to || disambiguated.Value.Cmp(big.NewInt(42)) != 0 {
+		t.Errorf("unexpected values: %+v", disambiguated)
+	}
+
+	var missing struct {
+		To common.Address `abi:"recipient"`
+	}
+	if err := abi.Unpack(&missing, "underscored", buff.Bytes()); err == nil {
+		t.Error("expected error for tag without argument")
+	}
+	var unmatched struct {
+		To common.Address
+	}
+	err = abi.Unpack(&unmatched, "underscored", buff.Bytes())
+	if err == nil || !strings.Contains(err.Error(), `"_value"`) || !strings.Contains(err.Error(), "struct { To common.Address }") {
+		t.Errorf("expected error naming the argument without field and the struct, got %v", err)
+	}
+	var duplicated struct {
+		A common.Address `abi:"_to"`
+		B common.Address `abi:"_to"`
+	}
+	if err := abi.Unpack(&duplicated, "underscored", buff.Bytes()); err == nil {
+		t.Error("expected error for duplicated tag")
+	}
+}
+
 func TestUnmarshal(t *testing.T) {
 	const definition = `[
 	{ "name" : "int", "constant" : false, "outputs": [ { "type": "uint256" } ] },
@@ -584,3 +708,137 @@ func TestUnmarshal(t *testing.T) {
 		t.Fatal("expected error:", err)
 	}
 }
+
+func TestUnpackTuple(t *testing.T) {
+	const definition = `[
+	{ "name" : "tuple", "constant" : true, "outputs": [ { "name": "s", "type": "tuple", "components": [ { "name": "a", "type": "uint256" }, { "name": "b", "type": "string" } ] }, { "name": "c", "type": "uint256" } ] },
+	{ "name" : "static", "constant" : true, "outputs": [ { "name": "s", "type": "tuple", "components": [ { "name": "a", "type": "uint256" }, { "name": "b", "type": "bool" } ] }, { "name": "c", "type": "uint256" } ] },
+	{ "name" : "slice", "constant" : true, "outputs": [ { "name": "s", "type": "tuple[]", "components": [ { "name": "a", "type": "uint256" }, { "name": "b", "type": "bool" } ] } ] }]`
+
+	abi, err := JSON(strings.NewReader(definition))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buff := new(bytes.Buffer)
+	buff.Write(common.Hex2Bytes("0000000000000000000000000000000000000000000000000000000000000040")) // offset of s
+	buff.Write(common.Hex2Bytes("0000000000000000000000000000000000000000000000000000000000000002")) // c
+	buff.Write(common.Hex2Bytes("0000000000000000000000000000000000000000000000000000000000000001")) // s.a
+	buff.Write(common.Hex2Bytes("0000000000000000000000000000000000000000000000000000000000000040")) // offset of s.b
+	buff.Write(common.Hex2Bytes("0000000000000000000000000000000000000000000000000000000000000002")) // len(s.b)
+	buff.Write(common.RightPadBytes([]byte("hi"), 32))
+
+	var dynamic struct {
+		S struct {
+			A *big.Int
+			B string
+		}
+		C *big.Int
+	}
+	if err := abi.Unpack(&dynamic, "tuple", buff.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if dynamic.S.A.Cmp(big.NewInt(1)) != 0 || dynamic.S.B != "hi" || dynamic.C.Cmp(big.NewInt(2)) != 0 {
+		t.Errorf("dynamic tuple mismatch: have %v", dynamic)
+	}
+
+	buff.Reset()
+	buff.Write(common.Hex2Bytes("0000000000000000000000000000000000000000000000000000000000000001")) // s.a
+	buff.Write(common.Hex2Bytes("0000000000000000000000000000000000000000000000000000000000000001")) // s.b
+	buff.Write(common.Hex2Bytes("0000000000000000000000000000000000000000000000000000000000000003")) // c
+
+	var static []interface{}
+	static = append(static, new(struct {
+		A *big.Int
+		B bool
+	}), new(*big.Int))
+	if err := abi.Unpack(&static, "static", buff.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if c := *static[1].(**big.Int); c.Cmp(big.NewInt(3)) != 0 {
+		t.Errorf("value following static tuple mismatch: have %v, want 3", c)
+	}
+
+	buff.Reset()
+	buff.Write(common.Hex2Bytes("0000000000000000000000000000000000000000000000000000000000000020")) // offset of s
+	buff.Write(common.Hex2Bytes("0000000000000000000000000000000000000000000000000000000000000002")) // len(s)
+	buff.Write(common.Hex2Bytes("0000000000000000000000000000000000000000000000000000000000000001")) // s[0].a
+	buff.Write(common.Hex2Bytes("0000000000000000000000000000000000000000000000000000000000000001")) // s[0].b
+	buff.Write(common.Hex2Bytes("0000000000000000000000000000000000000000000000000000000000000002")) // s[1].a
+	buff.Write(common.Hex2Bytes("0000000000000000000000000000000000000000000000000000000000000000")) // s[1].b
+
+	var slice []struct {
+		A *big.Int
+		B bool
+	}
+	if err := abi.Unpack(&slice, "slice", buff.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if len(slice) != 2 || slice[0].A.Cmp(big.NewInt(1)) != 0 || !slice[0].B || slice[1].A.Cmp(big.NewInt(2)) != 0 || slice[1].B {
+		t.Errorf("tuple slice mismatch: have %v", slice)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	typ, err := NewType("tuple[]", ArgumentMarshaling{Name: "a", Type: "uint256"}, ArgumentMarshaling{Name: "", Type: "bool"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	unpacked := reflect.MakeSlice(typ.Type, 1, 1)
+	unpacked.Index(0).Field(0).Set(reflect.ValueOf(big.NewInt(7)))
+	unpacked.Index(0).Field(1).SetBool(true)
+
+	type tuple struct {
+		A      *big.Int `json:"a"`
+		Field1 bool     `json:""`
+	}
+	var out []tuple
+	if err := Convert(&out, unpacked.Interface()); err != nil {
+		t.Fatal(err)
+	}
+	if len(out) != 1 || out[0].A.Int64() != 7 || !out[0].Field1 {
+		t.Errorf("unexpected conversion %+v", out)
+	}
+	if err := Convert(&out, "text"); err == nil {
+		t.Error("expected error converting a string into tuples")
+	}
+	if err := Convert(out, unpacked.Interface()); err == nil {
+		t.Error("expected error converting into a non-pointer")
+	}
+}
+
+// Tests that nested dynamic types survive a pack/unpack round trip.
+func TestUnpackNestedDynamicRoundTrip(t *testing.T) {
+	for i, test := range []struct {
+		typ   string
+		value interface{}
+	}{
+		{"string[]", []string{"one", "two", "three"}},
+		{"bytes[]", [][]byte{{1, 2, 3}, {}, {4}}},
+		{"uint256[][]", [][]*big.Int{{big.NewInt(1), big.NewInt(2)}, {big.NewInt(3)}}},
+		{"string[2]", [2]string{"a", "b"}},
+		{"string[][2]", [2][]string{{"a", "b"}, {"c"}}},
+		{"uint8[2][]", [][2]uint8{{1, 2}, {3, 4}}},
+		{"bool[2][2]", [2][2]bool{{true, false}, {false, true}}},
+	} {
+		def := fmt.Sprintf(`[{ "name" : "method", "outputs": [ { "type": "%s" }, { "type": "uint256" } ], "inputs": [ { "type": "%s" }, { "type": "uint256" } ] }]`, test.typ, test.typ)
+		abi, err := JSON(strings.NewReader(def))
+		if err != nil {
+			t.Fatalf("test %d (%s): invalid abi: %v", i, test.typ, err)
+		}
+		packed, err := abi.Methods["method"].pack(test.value, big.NewInt(42))
+		if err != nil {
+			t.Fatalf("test %d (%s): pack failed: %v", i, test.typ, err)
+		}
+		out := []interface{}{reflect.New(reflect.TypeOf(test.value)).Interface(), new(*big.Int)}
+		if err := abi.Unpack(&out, "method", packed); err != nil {
+			t.Fatalf("test %d (%s): unpack failed: %v", i, test.typ, err)
+		}
+		if have := reflect.ValueOf(out[0]).Elem().Interface(); !reflect.DeepEqual(have, test.value) {
+			t.Errorf("test %d (%s): value mismatch: have %v, want %v", i, test.typ, have, test.value)
+		}
+		if have := *out[1].(**big.Int); have.Cmp(big.NewInt(42)) != 0 {
+			t.Errorf("test %d (%s): trailing value mismatch: have %v, want 42", i, test.typ, have)
+		}
+	}
+}
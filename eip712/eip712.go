@@ -0,0 +1,360 @@
+// Package eip712 hashes and signs EIP-712 typed structured data, as used by
+// permits, orders and other off-chain signatures verified by contracts.
+package eip712
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"ethereum-front/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	"math/big"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DomainType is the name of the struct type describing the signing domain.
+const DomainType = "EIP712Domain"
+
+// arraySuffix matches the array part of a field type, e.g. `[]` or `[2][]`.
+var arraySuffix = regexp.MustCompile(`(\[[0-9]*\])+$`)
+
+// Field is a named member of a struct type.
+type Field struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Types maps the struct type names to their members, in declaration order.
+type Types map[string][]Field
+
+// TypedData is the typed-data JSON document accepted by eth_signTypedData.
+type TypedData struct {
+	Types       Types                  `json:"types"`
+	PrimaryType string                 `json:"primaryType"`
+	Domain      map[string]interface{} `json:"domain"`
+	Message     map[string]interface{} `json:"message"`
+}
+
+// Signature is a secp256k1 signature split the way contracts take it.
+type Signature struct {
+	V uint8
+	R common.Hash
+	S common.Hash
+}
+
+// Bytes returns the 65 byte r || s || v form of the signature.
+func (s Signature) Bytes() []byte {
+	return append(append(s.R.Bytes(), s.S.Bytes()...), s.V)
+}
+
+// Parse decodes a typed-data JSON document. Numbers are kept exact, so
+// values above 2^53 can be given either as JSON numbers or as strings.
+func Parse(data []byte) (*TypedData, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var typed TypedData
+	if err := dec.Decode(&typed); err != nil {
+		return nil, errors.Wrap(err, "json decode")
+	}
+	if _, ok := typed.Types[DomainType]; !ok {
+		return nil, errors.Errorf("types lack %s", DomainType)
+	}
+	if _, ok := typed.Types[typed.PrimaryType]; !ok {
+		return nil, errors.Errorf("primary type %s is not defined", typed.PrimaryType)
+	}
+	return &typed, nil
+}
+
+// EncodeType returns the encoding of a struct type: its own signature, e.g.
+// `Mail(Person from,Person to,string contents)`, followed by the signatures
+// of all the struct types it references, sorted by name.
+func (d *TypedData) EncodeType(primary string) (string, error) {
+	if _, ok := d.Types[primary]; !ok {
+		return "", errors.Errorf("type %s is not defined", primary)
+	}
+	deps := make(map[string]bool)
+	d.dependencies(primary, deps)
+	delete(deps, primary)
+
+	names := []string{primary}
+	var rest []string
+	for name := range deps {
+		rest = append(rest, name)
+	}
+	sort.Strings(rest)
+	names = append(names, rest...)
+
+	var buf bytes.Buffer
+	for _, name := range names {
+		var members []string
+		for _, field := range d.Types[name] {
+			members = append(members, field.Type+" "+field.Name)
+		}
+		buf.WriteString(name + "(" + strings.Join(members, ",") + ")")
+	}
+	return buf.String(), nil
+}
+
+// dependencies collects the struct types referenced by typ, itself included.
+func (d *TypedData) dependencies(typ string, found map[string]bool) {
+	typ = arraySuffix.ReplaceAllString(typ, "")
+	fields, ok := d.Types[typ]
+	if !ok || found[typ] {
+		return
+	}
+	found[typ] = true
+	for _, field := range fields {
+		d.dependencies(field.Type, found)
+	}
+}
+
+// TypeHash returns the keccak256 hash of the encoding of a struct type.
+func (d *TypedData) TypeHash(primary string) (common.Hash, error) {
+	encoded, err := d.EncodeType(primary)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash([]byte(encoded)), nil
+}
+
+// HashStruct returns the hash of a struct value of the given type, i.e.
+// keccak256(typeHash || encodeData(data)).
+func (d *TypedData) HashStruct(primary string, data map[string]interface{}) (common.Hash, error) {
+	encoded, err := d.encodeData(primary, data)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash(encoded), nil
+}
+
+// DomainSeparator returns the hash of the signing domain.
+func (d *TypedData) DomainSeparator() (common.Hash, error) {
+	return d.HashStruct(DomainType, d.Domain)
+}
+
+// Hash returns the digest that is signed:
+// keccak256("\x19\x01" || domainSeparator || hashStruct(message)).
+func (d *TypedData) Hash() (common.Hash, error) {
+	separator, err := d.DomainSeparator()
+	if err != nil {
+		return common.Hash{}, errors.Wrap(err, "domain")
+	}
+	data := append([]byte{0x19, 0x01}, separator.Bytes()...)
+
+	// a document with the domain as its primary type signs the domain only
+	if d.PrimaryType != DomainType {
+		message, err := d.HashStruct(d.PrimaryType, d.Message)
+		if err != nil {
+			return common.Hash{}, errors.Wrap(err, "message")
+		}
+		data = append(data, message.Bytes()...)
+	}
+	return crypto.Keccak256Hash(data), nil
+}
+
+// encodeData returns the type hash of a struct type followed by the
+// encoding of every member value.
+func (d *TypedData) encodeData(primary string, data map[string]interface{}) ([]byte, error) {
+	typeHash, err := d.TypeHash(primary)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded := typeHash.Bytes()
+	for _, field := range d.Types[primary] {
+		value, ok := data[field.Name]
+		if !ok {
+			return nil, errors.Errorf("%s lacks field %s", primary, field.Name)
+		}
+		word, err := d.encodeValue(field.Type, value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s.%s", primary, field.Name)
+		}
+		encoded = append(encoded, word...)
+	}
+	return encoded, nil
+}
+
+// encodeValue encodes a member value into a single 32 byte word: structs,
+// arrays and dynamic values are hashed, atomic values are abi encoded.
+func (d *TypedData) encodeValue(typ string, value interface{}) ([]byte, error) {
+	if arraySuffix.MatchString(typ) {
+		items, ok := value.([]interface{})
+		if !ok {
+			return nil, errors.Errorf("%v is not an array %s", value, typ)
+		}
+		// strip the outermost dimension only
+		elem := typ[:strings.LastIndex(typ, "[")]
+		var encoded []byte
+		for i, item := range items {
+			word, err := d.encodeValue(elem, item)
+			if err != nil {
+				return nil, errors.Wrapf(err, "item %d", i)
+			}
+			encoded = append(encoded, word...)
+		}
+		return crypto.Keccak256(encoded), nil
+	}
+
+	if _, ok := d.Types[typ]; ok {
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("%v is not a struct %s", value, typ)
+		}
+		hash, err := d.HashStruct(typ, fields)
+		if err != nil {
+			return nil, err
+		}
+		return hash.Bytes(), nil
+	}
+
+	t, err := abi.NewType(typ)
+	if err != nil {
+		return nil, err
+	}
+	return encodeAtomic(t, value)
+}
+
+// encodeAtomic encodes a value of a non-struct, non-array type.
+func encodeAtomic(t abi.Type, value interface{}) ([]byte, error) {
+	switch t.T {
+	case abi.StringTy:
+		s, ok := value.(string)
+		if !ok {
+			return nil, errors.Errorf("%v is not string", value)
+		}
+		return crypto.Keccak256([]byte(s)), nil
+
+	case abi.BytesTy:
+		s, _ := value.(string)
+		b, err := hexutil.Decode(s)
+		if err != nil {
+			return nil, errors.Errorf("%v is not hex bytes", value)
+		}
+		return crypto.Keccak256(b), nil
+
+	case abi.FixedBytesTy:
+		s, _ := value.(string)
+		b, err := hexutil.Decode(s)
+		if err != nil || len(b) > t.Size {
+			return nil, errors.Errorf("%v is not %s", value, t.String())
+		}
+		return common.RightPadBytes(b, 32), nil
+
+	case abi.AddressTy:
+		s, ok := value.(string)
+		if !ok || !common.IsHexAddress(s) {
+			return nil, errors.Errorf("%v is not address", value)
+		}
+		return common.LeftPadBytes(common.HexToAddress(s).Bytes(), 32), nil
+
+	case abi.BoolTy:
+		b, ok := value.(bool)
+		if !ok {
+			return nil, errors.Errorf("%v is not bool", value)
+		}
+		if b {
+			return abi.U256(big.NewInt(1)), nil
+		}
+		return abi.U256(big.NewInt(0)), nil
+
+	case abi.IntTy, abi.UintTy:
+		n, err := parseInteger(t, value)
+		if err != nil {
+			return nil, err
+		}
+		return abi.U256(n), nil
+	}
+	return nil, errors.Errorf("unsupported type: %s", t.String())
+}
+
+// parseInteger parses a JSON number or a decimal or 0x prefixed hex string,
+// checking it fits the bits of the type.
+func parseInteger(t abi.Type, value interface{}) (*big.Int, error) {
+	var text string
+	switch v := value.(type) {
+	case json.Number:
+		text = v.String()
+	case string:
+		text = v
+	default:
+		return nil, errors.Errorf("%v is not %s", value, t.String())
+	}
+
+	var n *big.Int
+	var ok bool
+	if strings.HasPrefix(text, "0x") || strings.HasPrefix(text, "0X") {
+		n, ok = new(big.Int).SetString(text[2:], 16)
+	} else {
+		n, ok = new(big.Int).SetString(text, 10)
+	}
+	if !ok {
+		return nil, errors.Errorf("%s is not %s", text, t.String())
+	}
+
+	var min, max *big.Int
+	if t.T == abi.UintTy {
+		min, max = common.Big0, new(big.Int).Lsh(common.Big1, uint(t.Size))
+	} else {
+		max = new(big.Int).Lsh(common.Big1, uint(t.Size-1))
+		min = new(big.Int).Neg(max)
+	}
+	if n.Cmp(min) < 0 || n.Cmp(max) >= 0 {
+		return nil, errors.Errorf("%s overflows %s", text, t.String())
+	}
+	return n, nil
+}
+
+// Sign signs the digest of the typed data with the given key.
+func Sign(d *TypedData, key *ecdsa.PrivateKey) (common.Hash, Signature, error) {
+	hash, err := d.Hash()
+	if err != nil {
+		return common.Hash{}, Signature{}, err
+	}
+	sig, err := crypto.Sign(hash.Bytes(), key)
+	if err != nil {
+		return common.Hash{}, Signature{}, errors.Wrap(err, "sign")
+	}
+	return hash, Signature{
+		V: sig[64] + 27,
+		R: common.BytesToHash(sig[:32]),
+		S: common.BytesToHash(sig[32:64]),
+	}, nil
+}
+
+// Recover returns the address that signed the typed data. The signature is
+// the 65 byte r || s || v form, with v either 0/1 or 27/28.
+func Recover(d *TypedData, sig []byte) (common.Address, error) {
+	if len(sig) != 65 {
+		return common.Address{}, errors.Errorf("signature must be 65 bytes, got %d", len(sig))
+	}
+	hash, err := d.Hash()
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	rsv := common.CopyBytes(sig)
+	if rsv[64] >= 27 {
+		rsv[64] -= 27
+	}
+	pub, err := crypto.SigToPub(hash.Bytes(), rsv)
+	if err != nil {
+		return common.Address{}, errors.Wrap(err, "recover")
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
+// Verify reports whether the typed data was signed by the given address.
+func Verify(d *TypedData, sig []byte, signer common.Address) (bool, error) {
+	recovered, err := Recover(d, sig)
+	if err != nil {
+		return false, err
+	}
+	return recovered == signer, nil
+}
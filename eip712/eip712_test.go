@@ -0,0 +1,133 @@
+package eip712
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"testing"
+)
+
+// mail is the example of the EIP-712 specification.
+const mail = `{
+	"types": {
+		"EIP712Domain": [
+			{"name": "name", "type": "string"},
+			{"name": "version", "type": "string"},
+			{"name": "chainId", "type": "uint256"},
+			{"name": "verifyingContract", "type": "address"}
+		],
+		"Person": [
+			{"name": "name", "type": "string"},
+			{"name": "wallet", "type": "address"}
+		],
+		"Mail": [
+			{"name": "from", "type": "Person"},
+			{"name": "to", "type": "Person"},
+			{"name": "contents", "type": "string"}
+		]
+	},
+	"primaryType": "Mail",
+	"domain": {
+		"name": "Ether Mail",
+		"version": "1",
+		"chainId": 1,
+		"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+	},
+	"message": {
+		"from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+		"to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+		"contents": "Hello, Bob!"
+	}
+}`
+
+func TestMailHashes(t *testing.T) {
+	typed, err := Parse([]byte(mail))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encoded, err := typed.EncodeType("Mail")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Mail(Person from,Person to,string contents)Person(string name,address wallet)"; encoded != want {
+		t.Errorf("encode type: expected %s, got %s", want, encoded)
+	}
+
+	separator, err := typed.DomainSeparator()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := common.HexToHash("0xf2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f"); separator != want {
+		t.Errorf("domain separator: expected %x, got %x", want, separator)
+	}
+
+	message, err := typed.HashStruct(typed.PrimaryType, typed.Message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := common.HexToHash("0xc52c0ee5d84264471806290a3f2c4cecfc5490626bf912d01f240d7a274b371e"); message != want {
+		t.Errorf("struct hash: expected %x, got %x", want, message)
+	}
+
+	hash, err := typed.Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := common.HexToHash("0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2"); hash != want {
+		t.Errorf("digest: expected %x, got %x", want, hash)
+	}
+}
+
+func TestSignRecover(t *testing.T) {
+	typed, err := Parse([]byte(mail))
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, _ := crypto.ToECDSA(crypto.Keccak256([]byte("cow")))
+
+	_, sig, err := Sign(typed, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sig.V != 28 {
+		t.Errorf("v: expected 28, got %d", sig.V)
+	}
+	if want := common.HexToHash("0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d"); sig.R != want {
+		t.Errorf("r: expected %x, got %x", want, sig.R)
+	}
+	if want := common.HexToHash("0x07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b91562"); sig.S != want {
+		t.Errorf("s: expected %x, got %x", want, sig.S)
+	}
+
+	signer := common.HexToAddress("0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826")
+	ok, err := Verify(typed, sig.Bytes(), signer)
+	if err != nil || !ok {
+		t.Errorf("verify failed: %v", err)
+	}
+
+	typed.Message["contents"] = "Hello, Alice!"
+	if ok, _ := Verify(typed, sig.Bytes(), signer); ok {
+		t.Error("verified a signature of another message")
+	}
+}
+
+func TestEncodeErrors(t *testing.T) {
+	for i, test := range []struct {
+		field string
+		value interface{}
+		err   string
+	}{
+		{"contents", 1, "message: Mail.contents: 1 is not string"},
+		{"from", "Cow", "message: Mail.from: Cow is not a struct Person"},
+		{"to", map[string]interface{}{"name": "Bob"}, "message: Mail.to: Person lacks field wallet"},
+	} {
+		typed, err := Parse([]byte(mail))
+		if err != nil {
+			t.Fatal(err)
+		}
+		typed.Message[test.field] = test.value
+		if _, err := typed.Hash(); err == nil || err.Error() != test.err {
+			t.Errorf("%d: expected err %q, got %v", i, test.err, err)
+		}
+	}
+}
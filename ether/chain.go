@@ -0,0 +1,29 @@
+package ether
+
+import (
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"math/big"
+)
+
+// DetectChainID asks the node for the chain ID transactions are signed for.
+// Nodes predating eth_chainId are asked for their network ID instead, which
+// matches the chain ID on the public networks.
+func DetectChainID(client *rpc.Client) (*big.Int, error) {
+	var chain_id hexutil.Big
+	if err := client.CallContext(context.Background(), &chain_id, "eth_chainId"); err == nil {
+		return (*big.Int)(&chain_id), nil
+	}
+
+	var version string
+	if err := client.CallContext(context.Background(), &version, "net_version"); err != nil {
+		return nil, errors.Wrap(err, "net_version")
+	}
+	network_id, ok := new(big.Int).SetString(version, 10)
+	if !ok {
+		return nil, errors.Errorf("invalid network id %q", version)
+	}
+	return network_id, nil
+}
@@ -0,0 +1,435 @@
+package ether
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"ethereum-front/abi"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ParseValue converts a form value into the exact Go value the abi package
+// expects for t, e.g. uint8 into uint8, uint256 into *big.Int and bytes32
+// into [32]byte. Arrays and tuples are given as JSON, e.g. `["a,b","c"]` or
+// `{"to": "0x..", "amount": 1}`, though one-dimensional arrays of plain values
+// may also be given comma separated. Integers are accepted in decimal, as 0x
+// hex and in scientific notation like `1e18`.
+func ParseValue(t abi.Type, text string) (interface{}, error) {
+	var raw interface{} = text
+
+	switch t.T {
+	case abi.TupleTy, abi.SliceTy, abi.ArrayTy:
+		trimmed := strings.TrimSpace(text)
+		if t.T != abi.TupleTy && !strings.HasPrefix(trimmed, "[") && isPlain(*t.Elem) {
+			var items []interface{}
+			if trimmed != "" {
+				for _, item := range strings.Split(trimmed, ",") {
+					items = append(items, strings.TrimSpace(item))
+				}
+			}
+			raw = items
+			break
+		}
+
+		dec := json.NewDecoder(strings.NewReader(trimmed))
+		dec.UseNumber()
+		if err := dec.Decode(&raw); err != nil {
+			return nil, errors.Wrap(err, "json decode")
+		}
+	case abi.StringTy:
+	default:
+		raw = strings.TrimSpace(text)
+	}
+
+	value, err := convertJSONValue(t, raw)
+	if err != nil {
+		return nil, err
+	}
+	return value.Interface(), nil
+}
+
+// isPlain reports whether values of t are neither arrays nor tuples.
+func isPlain(t abi.Type) bool {
+	return t.T != abi.TupleTy && t.T != abi.SliceTy && t.T != abi.ArrayTy
+}
+
+// tupleFieldName returns the JSON key of the i-th field of a tuple, which is
+// the component name or Field<i> if it's unnamed, like the abi package names
+// the Go field.
+func tupleFieldName(t abi.Type, i int) string {
+	if name := t.TupleRawNames[i]; name != "" {
+		return name
+	}
+	return fmt.Sprintf("Field%d", i)
+}
+
+// convertJSONValue recursively converts a decoded JSON value into the exact
+// Go type the abi package expects for t. Plain values may always be given as
+// strings.
+func convertJSONValue(t abi.Type, raw interface{}) (reflect.Value, error) {
+	switch t.T {
+	case abi.TupleTy:
+		result := reflect.New(t.Type).Elem()
+
+		switch fields := raw.(type) {
+		case map[string]interface{}:
+			for i, elem := range t.TupleElems {
+				name := tupleFieldName(t, i)
+				item, ok := fields[name]
+				if !ok {
+					return reflect.Value{}, errors.Errorf("missing tuple field %s", name)
+				}
+				value, err := convertJSONValue(*elem, item)
+				if err != nil {
+					return reflect.Value{}, errors.Wrapf(err, "tuple field %s", name)
+				}
+				result.Field(i).Set(value)
+			}
+		case []interface{}:
+			if len(fields) != len(t.TupleElems) {
+				return reflect.Value{}, errors.Errorf("tuple %s expects %d fields, got %d", t.String(), len(t.TupleElems), len(fields))
+			}
+			for i, elem := range t.TupleElems {
+				value, err := convertJSONValue(*elem, fields[i])
+				if err != nil {
+					return reflect.Value{}, errors.Wrapf(err, "tuple field %d", i)
+				}
+				result.Field(i).Set(value)
+			}
+		default:
+			return reflect.Value{}, errors.Errorf("%v is not a tuple %s", raw, t.String())
+		}
+		return result, nil
+
+	case abi.SliceTy, abi.ArrayTy:
+		items, ok := raw.([]interface{})
+		if !ok {
+			return reflect.Value{}, errors.Errorf("%v is not an array %s", raw, t.String())
+		}
+
+		var result reflect.Value
+		if t.T == abi.SliceTy {
+			result = reflect.MakeSlice(t.Type, len(items), len(items))
+		} else {
+			if len(items) != t.Size {
+				return reflect.Value{}, errors.Errorf("array %s expects %d items, got %d", t.String(), t.Size, len(items))
+			}
+			result = reflect.New(t.Type).Elem()
+		}
+		for i, item := range items {
+			value, err := convertJSONValue(*t.Elem, item)
+			if err != nil {
+				return reflect.Value{}, errors.Wrapf(err, "item %d", i)
+			}
+			result.Index(i).Set(value)
+		}
+		return result, nil
+
+	case abi.IntTy, abi.UintTy:
+		var text string
+		switch v := raw.(type) {
+		case json.Number:
+			text = v.String()
+		case string:
+			text = v
+		default:
+			return reflect.Value{}, errors.Errorf("%v is not %s", raw, t.String())
+		}
+		bi, err := parseInteger(text)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if t.T == abi.UintTy && bi.Sign() < 0 {
+			return reflect.Value{}, errors.Errorf("%s is not %s", text, t.String())
+		}
+		if !fitsBits(t, bi) {
+			return reflect.Value{}, errors.Errorf("%s overflows %s", text, t.String())
+		}
+		if t.Type == reflect.TypeOf(bi) {
+			return reflect.ValueOf(bi), nil
+		}
+		if t.T == abi.UintTy {
+			return reflect.ValueOf(bi.Uint64()).Convert(t.Type), nil
+		}
+		return reflect.ValueOf(bi.Int64()).Convert(t.Type), nil
+
+	case abi.BoolTy:
+		switch v := raw.(type) {
+		case bool:
+			return reflect.ValueOf(v), nil
+		case string:
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return reflect.Value{}, errors.Errorf("%s is not bool", v)
+			}
+			return reflect.ValueOf(b), nil
+		}
+		return reflect.Value{}, errors.Errorf("%v is not bool", raw)
+
+	case abi.StringTy:
+		s, ok := raw.(string)
+		if !ok {
+			return reflect.Value{}, errors.Errorf("%v is not string", raw)
+		}
+		return reflect.ValueOf(s), nil
+
+	case abi.AddressTy:
+		s, ok := raw.(string)
+		if !ok || !common.IsHexAddress(s) {
+			return reflect.Value{}, errors.Errorf("%v is not address", raw)
+		}
+		return reflect.ValueOf(common.HexToAddress(s)), nil
+
+	case abi.BytesTy:
+		s, ok := raw.(string)
+		if !ok {
+			return reflect.Value{}, errors.Errorf("%v is not bytes", raw)
+		}
+		// text without the 0x prefix is taken as its bytes
+		if !strings.HasPrefix(s, "0x") && !strings.HasPrefix(s, "0X") {
+			return reflect.ValueOf([]byte(s)), nil
+		}
+		b, err := decodeHex(s)
+		if err != nil {
+			return reflect.Value{}, errors.Errorf("%s is not hex bytes", s)
+		}
+		return reflect.ValueOf(b), nil
+
+	case abi.FixedBytesTy:
+		s, _ := raw.(string)
+		b, err := decodeHex(s)
+		if err != nil {
+			return reflect.Value{}, errors.Errorf("%v is not hex bytes", raw)
+		}
+		if len(b) > t.Size {
+			return reflect.Value{}, errors.Errorf("%s is longer than %s", s, t.String())
+		}
+		result := reflect.New(t.Type).Elem()
+		reflect.Copy(result, reflect.ValueOf(b))
+		return result, nil
+
+	case abi.FixedPointTy:
+		var text string
+		switch v := raw.(type) {
+		case json.Number:
+			text = v.String()
+		case string:
+			text = v
+		default:
+			return reflect.Value{}, errors.Errorf("%v is not %s", raw, t.String())
+		}
+		f, err := abi.ParseFixed(text, t.Decimals)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(f), nil
+
+	case abi.FunctionTy:
+		s, _ := raw.(string)
+		fn, err := decodeFunction(s)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(fn), nil
+	}
+	return reflect.Value{}, errors.Errorf("unsupported type: %s", t.String())
+}
+
+// FormatValue formats a value unpacked for t the way ParseValue reads it:
+// arrays as `[ a,b ]`, tuples as `{name: value, ...}` and bytes as 0x hex.
+func FormatValue(t abi.Type, value interface{}) string {
+	return formatValue(t, reflect.ValueOf(value))
+}
+
+func formatValue(t abi.Type, v reflect.Value) string {
+	if !v.IsValid() {
+		return "<nil>"
+	}
+	// indexed dynamic event arguments are only known by their hash
+	if hash, ok := v.Interface().(common.Hash); ok {
+		return hash.String()
+	}
+
+	switch t.T {
+	case abi.TupleTy:
+		v = reflect.Indirect(v)
+		items := make([]string, len(t.TupleElems))
+		for i, elem := range t.TupleElems {
+			items[i] = tupleFieldName(t, i) + ": " + formatValue(*elem, v.Field(i))
+		}
+		return "{" + strings.Join(items, ", ") + "}"
+
+	case abi.SliceTy, abi.ArrayTy:
+		items := make([]string, v.Len())
+		for i := range items {
+			items[i] = formatValue(*t.Elem, v.Index(i))
+		}
+		return "[ " + strings.Join(items, ",") + " ]"
+
+	case abi.AddressTy:
+		return common.BytesToAddress(valueBytes(v)).String()
+
+	case abi.BytesTy, abi.FixedBytesTy, abi.FunctionTy:
+		return hexutil.Encode(valueBytes(v))
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// JSONValue converts a value unpacked for t into a JSON encodable value:
+// integers and fixed point numbers become exact JSON numbers, bytes and
+// addresses 0x hex strings, arrays JSON arrays and tuples objects keyed by
+// component name.
+func JSONValue(t abi.Type, value interface{}) interface{} {
+	return jsonValue(t, reflect.ValueOf(value))
+}
+
+func jsonValue(t abi.Type, v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+	if hash, ok := v.Interface().(common.Hash); ok {
+		return hash.String()
+	}
+
+	switch t.T {
+	case abi.TupleTy:
+		v = reflect.Indirect(v)
+		fields := make(map[string]interface{}, len(t.TupleElems))
+		for i, elem := range t.TupleElems {
+			fields[tupleFieldName(t, i)] = jsonValue(*elem, v.Field(i))
+		}
+		return fields
+
+	case abi.SliceTy, abi.ArrayTy:
+		items := make([]interface{}, v.Len())
+		for i := range items {
+			items[i] = jsonValue(*t.Elem, v.Index(i))
+		}
+		return items
+
+	case abi.IntTy, abi.UintTy, abi.FixedPointTy:
+		return json.Number(fmt.Sprint(v.Interface()))
+
+	case abi.BoolTy, abi.StringTy:
+		return v.Interface()
+	}
+	return formatValue(t, v)
+}
+
+// valueBytes returns the bytes held by a byte slice or array.
+func valueBytes(v reflect.Value) []byte {
+	if v.Kind() == reflect.Slice {
+		return v.Bytes()
+	}
+	b := make([]byte, v.Len())
+	reflect.Copy(reflect.ValueOf(b), v)
+	return b
+}
+
+// fitsBits reports whether the integer is in the range of the intN or uintN
+// type t.
+func fitsBits(t abi.Type, bi *big.Int) bool {
+	if t.T == abi.UintTy {
+		return bi.Sign() >= 0 && bi.BitLen() <= t.Size
+	}
+	max := new(big.Int).Lsh(big.NewInt(1), uint(t.Size-1))
+	return bi.Cmp(new(big.Int).Neg(max)) >= 0 && bi.Cmp(max) < 0
+}
+
+// parseInteger parses an integer given in decimal, as 0x prefixed hex or in
+// scientific notation, e.g. `1e18` or `1.5e3`, as long as it is whole.
+func parseInteger(text string) (*big.Int, error) {
+	digits, neg := text, false
+	if strings.HasPrefix(digits, "-") {
+		digits, neg = digits[1:], true
+	}
+
+	base := 10
+	if strings.HasPrefix(digits, "0x") || strings.HasPrefix(digits, "0X") {
+		digits, base = digits[2:], 16
+	}
+	// the only sign allowed is the one before the prefix
+	if strings.HasPrefix(digits, "-") || strings.HasPrefix(digits, "+") {
+		return nil, errors.Errorf("%s is not an integer", text)
+	}
+
+	var bi *big.Int
+	var ok bool
+	if base == 10 && strings.ContainsAny(digits, "eE.") {
+		bi, ok = parseScientific(digits)
+	} else {
+		bi, ok = new(big.Int).SetString(digits, base)
+	}
+	if !ok {
+		return nil, errors.Errorf("%s is not an integer", text)
+	}
+	if neg {
+		bi.Neg(bi)
+	}
+	return bi, nil
+}
+
+// parseScientific parses an unsigned number like `1.5e3` exactly, failing if
+// it has a fractional part.
+func parseScientific(text string) (*big.Int, bool) {
+	mantissa, exp := text, 0
+	if i := strings.IndexAny(text, "eE"); i >= 0 {
+		var err error
+		if exp, err = strconv.Atoi(text[i+1:]); err != nil || exp > 1000 || exp < -1000 {
+			return nil, false
+		}
+		mantissa = text[:i]
+	}
+	whole, frac := mantissa, ""
+	if i := strings.Index(mantissa, "."); i >= 0 {
+		whole, frac = mantissa[:i], mantissa[i+1:]
+	}
+	if whole == "" && frac == "" {
+		return nil, false
+	}
+
+	// move the decimal point by the exponent
+	exp -= len(frac)
+	digits := whole + frac
+	if exp < 0 {
+		if -exp > len(digits) {
+			digits = strings.Repeat("0", -exp-len(digits)) + digits
+		}
+		if strings.Trim(digits[len(digits)+exp:], "0") != "" {
+			return nil, false
+		}
+		digits = digits[:len(digits)+exp]
+		if digits == "" {
+			digits = "0"
+		}
+	} else {
+		digits += strings.Repeat("0", exp)
+	}
+	return new(big.Int).SetString(digits, 10)
+}
+
+// decodeHex decodes a 0x prefixed hex string.
+func decodeHex(s string) ([]byte, error) {
+	if !strings.HasPrefix(s, "0x") && !strings.HasPrefix(s, "0X") {
+		return nil, errors.Errorf("%s is not 0x prefixed", s)
+	}
+	return hex.DecodeString(s[2:])
+}
+
+// decodeFunction decodes the value of a function type, given as the 0x
+// prefixed hex of the contract address immediately followed by the selector.
+func decodeFunction(s string) ([24]byte, error) {
+	var fn [24]byte
+	b, err := decodeHex(s)
+	if err != nil || len(b) != len(fn) {
+		return fn, errors.Errorf("%s is not function (address followed by selector)", s)
+	}
+	copy(fn[:], b)
+	return fn, nil
+}
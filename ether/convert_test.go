@@ -0,0 +1,136 @@
+package ether
+
+import (
+	"encoding/json"
+	"ethereum-front/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestParseValue(t *testing.T) {
+	for i, test := range []struct {
+		typ   string
+		input string
+		want  interface{}
+		out   string
+	}{
+		{"uint8", "7", uint8(7), "7"},
+		{"int256", "-5", big.NewInt(-5), "-5"},
+		{"bool", "true", true, "true"},
+		{"string", " a, b ", " a, b ", " a, b "},
+		{"bytes", "0x0102", []byte{1, 2}, "0x0102"},
+		{"bytes", "hi", []byte("hi"), "0x6869"},
+		{"bytes32", "0x01", [32]byte{1}, "0x0100000000000000000000000000000000000000000000000000000000000000"},
+		{"address", "0x0000000000000000000000000000000000000001", common.Address{19: 1}, "0x0000000000000000000000000000000000000001"},
+		{"uint256[3]", "1, 2, 3", [3]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)}, "[ 1,2,3 ]"},
+		{"uint16[2][]", "[[1,2],[3,4]]", [][2]uint16{{1, 2}, {3, 4}}, "[ [ 1,2 ],[ 3,4 ] ]"},
+		{"address[2]", `["0x0000000000000000000000000000000000000001","0x0000000000000000000000000000000000000002"]`, [2]common.Address{{19: 1}, {19: 2}}, "[ 0x0000000000000000000000000000000000000001,0x0000000000000000000000000000000000000002 ]"},
+		{"bool[]", "true,false", []bool{true, false}, "[ true,false ]"},
+		{"string[]", `["a,b","c"]`, []string{"a,b", "c"}, "[ a,b,c ]"},
+		{"uint256", "0xff", big.NewInt(255), "255"},
+		{"int64", "-0x10", int64(-16), "-16"},
+		{"uint256", "1e18", big.NewInt(1000000000000000000), "1000000000000000000"},
+		{"uint32", "1.5e3", uint32(1500), "1500"},
+		{"uint256[]", `[1e3, "0x10", "7"]`, []*big.Int{big.NewInt(1000), big.NewInt(16), big.NewInt(7)}, "[ 1000,16,7 ]"},
+	} {
+		typ, err := abi.NewType(test.typ)
+		if err != nil {
+			t.Fatalf("%d: %v", i, err)
+		}
+		value, err := ParseValue(typ, test.input)
+		if err != nil {
+			t.Errorf("%d: unexpected error: %v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(value, test.want) {
+			t.Errorf("%d: expected %#v, got %#v", i, test.want, value)
+		}
+		if out := FormatValue(typ, value); out != test.out {
+			t.Errorf("%d: expected output %s, got %s", i, test.out, out)
+		}
+	}
+}
+
+func TestParseValueErrors(t *testing.T) {
+	for i, test := range []struct {
+		typ   string
+		input string
+		err   string
+	}{
+		{"uint8", "300", "300 overflows uint8"},
+		{"uint256", "-1", "-1 is not uint256"},
+		{"uint8[2]", "1,2,3", "array uint8[2] expects 2 items, got 3"},
+		{"address", "0x01", "0x01 is not address"},
+		{"bytes2", "0x010203", "0x010203 is longer than bytes2"},
+		{"bytes", "0x0g", "0x0g is not hex bytes"},
+		{"bytes", "0x123", "0x123 is not hex bytes"},
+		{"uint24", "0x1000000", "0x1000000 overflows uint24"},
+		{"int256", "1.5", "1.5 is not an integer"},
+		{"uint256", "15e-1", "15e-1 is not an integer"},
+		{"uint256", "0x", "0x is not an integer"},
+		{"int256", "0x-5", "0x-5 is not an integer"},
+		{"int256", "-0x-5", "-0x-5 is not an integer"},
+		{"uint8[]", "[1, 2.5]", "item 1: 2.5 is not an integer"},
+	} {
+		typ, err := abi.NewType(test.typ)
+		if err != nil {
+			t.Fatalf("%d: %v", i, err)
+		}
+		if _, err := ParseValue(typ, test.input); err == nil || err.Error() != test.err {
+			t.Errorf("%d: expected err %q, got %v", i, test.err, err)
+		}
+	}
+}
+
+func TestJSONValue(t *testing.T) {
+	for i, test := range []struct {
+		typ   string
+		value interface{}
+		json  string
+	}{
+		{"uint256", new(big.Int).Lsh(big.NewInt(1), 100), `1267650600228229401496703205376`},
+		{"int8", int8(-5), `-5`},
+		{"bool", true, `true`},
+		{"string", "a,b", `"a,b"`},
+		{"bytes", []byte{0xde, 0xad}, `"0xdead"`},
+		{"bytes2", [2]byte{0xbe, 0xef}, `"0xbeef"`},
+		{"uint8[]", []uint8{1, 2}, `[1,2]`},
+		{"ufixed128x2", abi.NewFixed(big.NewInt(150), 2), `1.50`},
+	} {
+		typ, err := abi.NewType(test.typ)
+		if err != nil {
+			t.Fatalf("%d: %v", i, err)
+		}
+		b, err := json.Marshal(JSONValue(typ, test.value))
+		if err != nil {
+			t.Errorf("%d: %v", i, err)
+			continue
+		}
+		if string(b) != test.json {
+			t.Errorf("%d: expected %s, got %s", i, test.json, b)
+		}
+	}
+}
+
+func TestJSONValueTuple(t *testing.T) {
+	typ, err := abi.NewType("tuple", abi.ArgumentMarshaling{Name: "", Type: "uint256"}, abi.ArgumentMarshaling{Name: "", Type: "bool"}, abi.ArgumentMarshaling{Name: "owner", Type: "address"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, err := ParseValue(typ, `{"Field0": "5", "Field1": true, "owner": "0x0000000000000000000000000000000000000001"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(JSONValue(typ, value))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"Field0":5,"Field1":true,"owner":"0x0000000000000000000000000000000000000001"}`; string(b) != want {
+		t.Errorf("expected %s, got %s", want, b)
+	}
+	if _, err := ParseValue(typ, `{"Field0": "5", "owner": "0x0000000000000000000000000000000000000001"}`); err == nil || err.Error() != "missing tuple field Field1" {
+		t.Errorf("expected missing field error, got %v", err)
+	}
+}
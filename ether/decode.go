@@ -0,0 +1,96 @@
+package ether
+
+import (
+	"ethereum-front/abi"
+	"ethereum-front/templates"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	"strings"
+)
+
+// decodeLogs formats the logs of a receipt as named events with their
+// argument values, e.g. `Transfer(from: 0x.., to: 0x.., value: 1000)`, one per
+// line.
+func decodeLogs(logs []*types.Log) string {
+	var result string
+	for i, log := range logs {
+		result += fmt.Sprintf(templates.EventResult, i, decodeLog(*log), log.Address.String())
+	}
+	return result
+}
+
+// decodeLog decodes a log against the abis of all the loaded contracts.
+// Contracts often share event signatures (e.g. the Transfer event of ERC20
+// and ERC721 tokens differs only in the indexed inputs) so every event with
+// a matching id is tried until one of them decodes the log.
+func decodeLog(log types.Log) string {
+	if len(log.Topics) == 0 {
+		return "anonymous event"
+	}
+	if containers := LoadContainers(); containers != nil {
+		for _, container_name := range containers.ContainerNames {
+			container := containers.Containers[container_name]
+
+			for _, contract_name := range container.ContractNames {
+				event, err := container.Contracts[contract_name].Abi.EventById(log.Topics[0])
+				if err != nil {
+					continue
+				}
+				values, err := event.ParseLog(log)
+				if err != nil {
+					continue
+				}
+
+				return event.Name + formatArguments(event.Inputs, values)
+			}
+		}
+	}
+	return "unknown event " + log.Topics[0].String()
+}
+
+// DecodeCalldata decodes the input of a transaction against all the loaded
+// contracts, listing every contract having a method with a matching selector
+// along with the decoded call, e.g. `token.sol/Token: transfer(to: 0x.., value: 1)`.
+func DecodeCalldata(data []byte) (string, error) {
+	var calls []string
+
+	containers := LoadContainers()
+	for _, container_name := range containers.ContainerNames {
+		container := containers.Containers[container_name]
+
+		for _, contract_name := range container.ContractNames {
+			ab := container.Contracts[contract_name].Abi
+
+			method, err := ab.MethodById(data)
+			if err != nil {
+				continue
+			}
+			values, err := ab.UnpackInput(method.Name, data)
+			if err != nil {
+				calls = append(calls, fmt.Sprintf("%s/%s: %s: %s", container_name, contract_name, method.Sig(), err.Error()))
+				continue
+			}
+			calls = append(calls, fmt.Sprintf("%s/%s: %s%s", container_name, contract_name, method.RawName, formatArguments(method.Inputs, values)))
+		}
+	}
+
+	if len(calls) == 0 {
+		if len(data) < 4 {
+			return "", errors.Errorf("call data too short: %d bytes", len(data))
+		}
+		return "", errors.Errorf("no method with selector %s", hexutil.Encode(data[:4]))
+	}
+	return strings.Join(calls, "\n"), nil
+}
+
+// formatArguments formats decoded arguments in order as `(name: value, ...)`.
+func formatArguments(args []abi.Argument, values map[string]interface{}) string {
+	items := make([]string, len(args))
+	for i := range args {
+		name := abi.ArgumentName(args, i)
+		items[i] = name + ": " + FormatValue(args[i].Type, values[name])
+	}
+	return "(" + strings.Join(items, ", ") + ")"
+}
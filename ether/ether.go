@@ -4,9 +4,9 @@ import (
 	"encoding/json"
 	"ethereum-front/abi"
 	"ethereum-front/abi/bind"
+	"ethereum-front/abi/bind/backends"
 	"ethereum-front/templates"
 	"fmt"
-	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/compiler"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -19,19 +19,21 @@ import (
 	"math/big"
 	"net/url"
 	"os"
+	"reflect"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 )
 
 //Object satisfying the interface should be able to:
 type ReadWriterEth interface {
 	Transact() (string, error)
-	Call() (string, error)
+	Call() ([]Output, error)
 	Deploy() (string, string, error)
 	Info() (*Info, error)
 	ParseInput() ([]interface{}, error)
-	ParseOutput([]interface{}) (string, error)
+	ParseOutput([]interface{}) ([]Output, error)
 }
 
 type Info struct {
@@ -41,6 +43,28 @@ type Info struct {
 	Container       string `json:"sol_file"`
 	Contract        string `json:"contract"`
 	ContractAddress string `json:"contract_address"`
+	ChainID         string `json:"chain_id"`
+}
+
+// Output is a value returned by a call, along with the name and solidity type
+// of the return value. Value is JSON encodable, see JSONValue.
+type Output struct {
+	Name  string      `json:"name"`
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+// String formats the value for display: strings as they are and anything
+// else as JSON.
+func (o Output) String() string {
+	if s, ok := o.Value.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(o.Value)
+	if err != nil {
+		return fmt.Sprint(o.Value)
+	}
+	return string(b)
 }
 
 type EthWorker struct {
@@ -51,6 +75,9 @@ type EthWorker struct {
 	ContractAddress string
 	FormValues      url.Values
 	New             bool
+
+	// Amounts echoes the amounts converted by ParseInput to raw values.
+	Amounts []string
 }
 
 type ContractContainers struct {
@@ -79,8 +106,34 @@ var (
 	Client     bind.ContractBackend
 	Containers *ContractContainers
 	GasLimit   *big.Int
+	ChainID    *big.Int // Chain transactions are replay protected for (EIP-155)
+
+	// ContainersMu guards swapping Containers once serving. AttachContract
+	// replaces it with an updated copy, so the snapshot LoadContainers returns
+	// is never modified and is read without holding the lock.
+	ContainersMu sync.RWMutex
 )
 
+// LoadContainers returns a snapshot of the loaded contract containers.
+func LoadContainers() *ContractContainers {
+	ContainersMu.RLock()
+	defer ContainersMu.RUnlock()
+	return Containers
+}
+
+// Contract returns the named contract of a container, nil if there is none.
+func (c *ContractContainers) Contract(container_name, contract_name string) *Contract {
+	if container := c.Containers[container_name]; container != nil {
+		return container.Contracts[contract_name]
+	}
+	return nil
+}
+
+// contract returns the contract the worker operates on, nil if it's not loaded.
+func (w *EthWorker) contract() *Contract {
+	return LoadContainers().Contract(w.Container, w.Contract)
+}
+
 func NewEthWorker(
 	container,
 	contract,
@@ -112,7 +165,7 @@ func (w *EthWorker) Transact() (string, error) {
 	if err != nil {
 		return "", errors.Wrap(err, "hex to ECDSA")
 	}
-	auth := bind.NewKeyedTransactor(key)
+	auth := bind.NewKeyedTransactorWithChainID(key, ChainID)
 
 	if !common.IsHexAddress(w.ContractAddress) {
 		return "", errors.New("New Address From Hex")
@@ -120,9 +173,15 @@ func (w *EthWorker) Transact() (string, error) {
 
 	addr := common.HexToAddress(w.ContractAddress)
 
+	value, err := w.TxValue(w.contract().Abi.Methods[w.Endpoint], auth.From)
+	if err != nil {
+		return "", errors.Wrap(err, "value")
+	}
+
 	contract := bind.NewBoundContract(
 		addr,
-		Containers.Containers[w.Container].Contracts[w.Contract].Abi,
+		w.contract().Abi,
+		Client,
 		Client,
 		Client,
 	)
@@ -135,9 +194,13 @@ func (w *EthWorker) Transact() (string, error) {
 	opt := &bind.TransactOpts{
 		From:     auth.From,
 		Signer:   auth.Signer,
+		ChainID:  auth.ChainID,
 		GasPrice: gasprice,
 		GasLimit: GasLimit,
-		Value:    auth.Value,
+		Value:    value,
+	}
+	if err := ApplyTxOptions(w.FormValues, opt); err != nil {
+		return "", errors.Wrap(err, "transaction options")
 	}
 
 	tr, err := contract.Transact(opt, w.Endpoint, inputs...)
@@ -181,14 +244,22 @@ func (w *EthWorker) Transact() (string, error) {
 		receipt.TxHash.String(),
 	)
 
+	if receipt.Status == types.ReceiptStatusFailed {
+		if revert := contract.RevertReason(&bind.CallOpts{From: auth.From}, tr); revert != nil {
+			responce += fmt.Sprintf(templates.RevertResult, revert.Error())
+		}
+	}
+	responce += decodeLogs(receipt.Logs)
+	responce += strings.Join(w.Amounts, "")
+
 	return responce, nil
 }
 
-func (w *EthWorker) Call() (string, error) {
+func (w *EthWorker) Call() ([]Output, error) {
 
 	inputs, err := w.ParseInput()
 	if err != nil {
-		return "", errors.Wrap(err, "parse input")
+		return nil, errors.Wrap(err, "parse input")
 	}
 
 	key, _ := crypto.GenerateKey()
@@ -196,7 +267,8 @@ func (w *EthWorker) Call() (string, error) {
 
 	contract := bind.NewBoundContract(
 		common.HexToAddress(w.ContractAddress),
-		Containers.Containers[w.Container].Contracts[w.Contract].Abi,
+		w.contract().Abi,
+		Client,
 		Client,
 		Client,
 	)
@@ -205,8 +277,11 @@ func (w *EthWorker) Call() (string, error) {
 		Pending: true,
 		From:    auth.From,
 	}
+	if err := ApplyCallOptions(w.FormValues, opt); err != nil {
+		return nil, errors.Wrap(err, "call options")
+	}
 
-	outputs := Containers.Containers[w.Container].Contracts[w.Contract].OutputsInterfaces[w.Endpoint]
+	outputs := w.contract().OutputsInterfaces[w.Endpoint]
 
 	if err := contract.Call(
 		opt,
@@ -214,12 +289,12 @@ func (w *EthWorker) Call() (string, error) {
 		w.Endpoint,
 		inputs...,
 	); err != nil {
-		return "", errors.Wrap(err, "call contract")
+		return nil, errors.Wrap(err, "call contract")
 	}
 
 	result, err := w.ParseOutput(outputs)
 	if err != nil {
-		return "", errors.Wrap(err, "parse output")
+		return nil, errors.Wrap(err, "parse output")
 	}
 
 	return result, err
@@ -237,12 +312,22 @@ func (w *EthWorker) Deploy() (string, string, error) {
 	if err != nil {
 		return "", "", errors.Wrap(err, "hex to ECDSA")
 	}
-	auth := bind.NewKeyedTransactor(key)
+	auth := bind.NewKeyedTransactorWithChainID(key, ChainID)
+	auth.GasLimit = GasLimit
 
-	current_bytecode := Containers.Containers[w.Container].Contracts[w.Contract].Bin
-	current_abi := Containers.Containers[w.Container].Contracts[w.Contract].Abi
+	current := w.contract()
+	auth.Value, err = w.TxValue(current.Abi.Constructor, auth.From)
+	if err != nil {
+		return "", "", errors.Wrap(err, "value")
+	}
+	if err := ApplyTxOptions(w.FormValues, auth); err != nil {
+		return "", "", errors.Wrap(err, "transaction options")
+	}
+
+	current_bytecode := current.Bin
+	current_abi := current.Abi
 
-	addr, tr, _, err := bind.DeployContract(auth, current_abi, common.FromHex(current_bytecode), Client, inputs...)
+	addr, tr, contract, err := bind.DeployContract(auth, current_abi, common.FromHex(current_bytecode), Client, inputs...)
 	if err != nil {
 		log.Printf("error %s", err.Error())
 		return "", "", errors.Wrap(err, "deploy contract")
@@ -282,6 +367,14 @@ func (w *EthWorker) Deploy() (string, string, error) {
 		receipt.TxHash.String(),
 	)
 
+	if receipt.Status == types.ReceiptStatusFailed {
+		if revert := contract.RevertReason(&bind.CallOpts{From: auth.From}, tr); revert != nil {
+			responce += fmt.Sprintf(templates.RevertResult, revert.Error())
+		}
+	}
+	responce += decodeLogs(receipt.Logs)
+	responce += strings.Join(w.Amounts, "")
+
 	return responce, addr.String(), nil
 }
 
@@ -296,26 +389,11 @@ func (w *EthWorker) Info() (*Info, error) {
 	}
 	keyAddr := crypto.PubkeyToAddress(key.PublicKey)
 
-	switch v := Client.(type) {
-	case *ethclient.Client:
-		balance, err := v.BalanceAt(
-			context.Background(),
-			keyAddr,
-			nil,
-		)
-		if err != nil {
-			return nil, errors.Wrap(err, "get balance")
-		}
-		result.Balance = balance.String()
-	case *backends.SimulatedBackend:
-		balance, err := v.BalanceAt(
-			context.Background(),
-			keyAddr,
-			nil,
-		)
-		if err != nil {
-			return nil, errors.Wrap(err, "get balance")
-		}
+	balance, err := balanceAt(keyAddr)
+	if err != nil {
+		return nil, errors.Wrap(err, "get balance")
+	}
+	if balance != nil {
 		result.Balance = balance.String()
 	}
 
@@ -338,16 +416,78 @@ func (w *EthWorker) Info() (*Info, error) {
 
 	result.ContractAddress = w.ContractAddress
 
+	if ChainID != nil {
+		result.ChainID = ChainID.String()
+	}
+
 	return result, err
 }
 
+// balanceAt returns the balance of the account, or nil if the backend can't
+// tell it.
+func balanceAt(account common.Address) (*big.Int, error) {
+	switch v := Client.(type) {
+	case *ethclient.Client:
+		return v.BalanceAt(context.Background(), account, nil)
+	case *backends.SimulatedBackend:
+		return v.BalanceAt(context.Background(), account, nil)
+	}
+	return nil, nil
+}
+
+// TxValue returns the ether sent along with the method, read from the value
+// field in wei or with a unit suffix. Non payable methods only accept an empty
+// or zero value, and the value may not exceed the balance of from.
+func (w *EthWorker) TxValue(method abi.Method, from common.Address) (*big.Int, error) {
+	text := strings.TrimSpace(w.FormValues.Get("value"))
+	if text == "" {
+		return nil, nil
+	}
+
+	value, err := ParseAmount(text, -1)
+	if err != nil {
+		return nil, err
+	}
+	if value.Sign() < 0 {
+		return nil, errors.Errorf("%s is negative", text)
+	}
+	if value.Sign() == 0 {
+		return nil, nil
+	}
+	if !method.IsPayable() {
+		name := method.Sig()
+		if method.Name == "" {
+			name = "constructor"
+		}
+		return nil, errors.Errorf("%s is not payable", name)
+	}
+
+	balance, err := balanceAt(from)
+	if err != nil {
+		return nil, errors.Wrap(err, "get balance")
+	}
+	if balance != nil && value.Cmp(balance) > 0 {
+		return nil, errors.Errorf("insufficient funds: value %s exceeds balance %s of %s", value, balance, from.String())
+	}
+
+	if HasUnit(text) {
+		w.Amounts = append(w.Amounts, fmt.Sprintf(templates.AmountResult, "value", text, value.String()))
+	}
+	return value, nil
+}
+
 func (w *EthWorker) ParseInput() ([]interface{}, error) {
 
-	if w.New && len(Containers.Containers[w.Container].Contracts[w.Contract].Abi.Constructor.Inputs) == 0 {
+	current := w.contract()
+	if current == nil {
+		return nil, errors.New("input values incorrect")
+	}
+
+	if w.New && len(current.Abi.Constructor.Inputs) == 0 {
 		return nil, nil
 	}
 
-	if !w.New && len(Containers.Containers[w.Container].Contracts[w.Contract].Abi.Methods[w.Endpoint].Inputs) == 0 {
+	if !w.New && len(current.Abi.Methods[w.Endpoint].Inputs) == 0 {
 		return nil, nil
 	}
 
@@ -372,20 +512,16 @@ func (w *EthWorker) ParseInput() ([]interface{}, error) {
 		inputsMap[i] = v[0]
 	}
 
-	if Containers.Containers[w.Container] == nil || Containers.Containers[w.Container].Contracts[w.Contract] == nil {
-		return nil, errors.New("input values incorrect")
-	}
-
-	if !w.New && len(Containers.Containers[w.Container].Contracts[w.Contract].Abi.Methods[w.Endpoint].Inputs) != 0 && Containers.Containers[w.Container].Contracts[w.Contract].InputsInterfaces[w.Endpoint] == nil {
+	if !w.New && len(current.Abi.Methods[w.Endpoint].Inputs) != 0 && current.InputsInterfaces[w.Endpoint] == nil {
 		return nil, errors.New("input values incorrect")
 	}
 
 	var inputs_args []abi.Argument
 
 	if w.New {
-		inputs_args = Containers.Containers[w.Container].Contracts[w.Contract].Abi.Constructor.Inputs
+		inputs_args = current.Abi.Constructor.Inputs
 	} else {
-		inputs_args = Containers.Containers[w.Container].Contracts[w.Contract].Abi.Methods[w.Endpoint].Inputs
+		inputs_args = current.Abi.Methods[w.Endpoint].Inputs
 	}
 
 	if len(inputsMap) != len(inputs_args) {
@@ -403,318 +539,114 @@ func (w *EthWorker) ParseInput() ([]interface{}, error) {
 
 	var inputs_interfaces []interface{}
 
-	for i := 0; i < len(inputs_args); i++ {
-
-		arg_value := inputsMap[i]
-
-		switch inputs_args[i].Type.Type.String() {
-		case "bool":
-			var result bool
-			result, err := strconv.ParseBool(arg_value)
-			if err != nil {
-				return nil, errors.New("incorrect inputs")
-			}
-			inputs_interfaces = append(inputs_interfaces, result)
-
-		case "[]bool":
-			var result []bool
-
-			result_array := strings.Split(arg_value, ",")
-
-			for _, bool_value := range result_array {
-				item, err := strconv.ParseBool(bool_value)
-				if err != nil {
-					return nil, errors.Wrap(err, "incorrect inputs")
-				}
-				result = append(result, item)
-			}
-			inputs_interfaces = append(inputs_interfaces, result)
-
-		case "string":
-			inputs_interfaces = append(inputs_interfaces, arg_value)
-		case "[]string":
-			result_array := strings.Split(arg_value, ",") //TODO: NEED REF
-			inputs_interfaces = append(inputs_interfaces, result_array)
-		case "[]byte":
-			inputs_interfaces = append(inputs_interfaces, []byte(arg_value))
-		case "[][]byte":
-			var result [][]byte
-
-			result_array := strings.Split(arg_value, ",")
-
-			for _, byte_value := range result_array {
-				result = append(result, []byte(byte_value))
-			}
-			inputs_interfaces = append(inputs_interfaces, result)
-
-		case "common.Address":
-			if !common.IsHexAddress(arg_value) {
-				return nil, errors.New("incorrect inputs: arg_value is not address")
-			}
-
-			inputs_interfaces = append(inputs_interfaces, common.HexToAddress(arg_value))
-		case "[]common.Address":
-			var result []common.Address
-
-			result_array := strings.Split(arg_value, ",")
-
-			for _, addr_value := range result_array {
-
-				if !common.IsHexAddress(arg_value) {
-					return nil, errors.New("incorrect inputs: arg_value is not address")
-				}
-
-				addr := common.HexToAddress(addr_value)
-
-				result = append(result, addr)
-			}
-			inputs_interfaces = append(inputs_interfaces, result)
-		case "common.Hash":
-			if !common.IsHex(arg_value) {
-				return nil, errors.New("incorrect inputs: arg_value is not hex")
-			}
-
-			inputs_interfaces = append(inputs_interfaces, common.HexToHash(arg_value))
-
-		case "[]common.Hash":
-			var result []common.Hash
-
-			result_array := strings.Split(arg_value, ",")
+	w.Amounts = nil
 
-			for _, addr_value := range result_array {
-
-				if !common.IsHex(arg_value) {
-					return nil, errors.New("incorrect inputs: arg_value is not hex")
-				}
-
-				hash := common.HexToHash(addr_value)
-				result = append(result, hash)
-			}
-			inputs_interfaces = append(inputs_interfaces, result)
-		case "int8":
-			i, err := strconv.ParseInt(arg_value, 10, 8)
-			if err != nil {
-				return nil, errors.New("incorrect inputs: arg_value is not int8")
-			}
-			inputs_interfaces = append(inputs_interfaces, int8(i))
-		case "int16":
-			i, err := strconv.ParseInt(arg_value, 10, 16)
-			if err != nil {
-				return nil, errors.New("incorrect inputs: arg_value is not int16")
-			}
-			inputs_interfaces = append(inputs_interfaces, int16(i))
-		case "int32":
-			i, err := strconv.ParseInt(arg_value, 10, 32)
-			if err != nil {
-				return nil, errors.New("incorrect inputs: arg_value is not int32")
-			}
-			inputs_interfaces = append(inputs_interfaces, int32(i))
-		case "int64":
-			i, err := strconv.ParseInt(arg_value, 10, 64)
-			if err != nil {
-				return nil, errors.New("incorrect inputs: arg_value is not int64")
-			}
-			inputs_interfaces = append(inputs_interfaces, int64(i))
-		case "uint8":
-			i, err := strconv.ParseInt(arg_value, 10, 8)
-			if err != nil {
-				return nil, errors.New("incorrect inputs: arg_value is not uint8")
-			}
-			inputs_interfaces = append(inputs_interfaces, big.NewInt(i))
-		case "uint16":
-			i, err := strconv.ParseInt(arg_value, 10, 16)
-			if err != nil {
-				return nil, errors.New("incorrect inputs: arg_value is not uint16")
-			}
-			inputs_interfaces = append(inputs_interfaces, big.NewInt(i))
-		case "uint32":
-			i, err := strconv.ParseInt(arg_value, 10, 32)
-			if err != nil {
-				return nil, errors.New("incorrect inputs: arg_value is not uint32")
-			}
-			inputs_interfaces = append(inputs_interfaces, big.NewInt(i))
-		case "uint64":
-			i, err := strconv.ParseInt(arg_value, 10, 64)
-			if err != nil {
-				return nil, errors.New("incorrect inputs: arg_value is not uint64")
-			}
-			inputs_interfaces = append(inputs_interfaces, big.NewInt(i))
-		case "*big.Int":
-			bi := new(big.Int)
-			bi, _ = bi.SetString(arg_value, 10)
-			if bi == nil {
-				return nil, errors.New("incorrect inputs: " + arg_value + " not " + inputs_args[i].Type.String())
-			}
-			inputs_interfaces = append(inputs_interfaces, bi)
-		case "[]*big.Int":
-			var result []*big.Int
-
-			result_array := strings.Split(arg_value, ",")
+	for i := 0; i < len(inputs_args); i++ {
+		text, err := w.parseAmount(inputs_args[i].Type, inputsMap[i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "argument %d (%s %s)", i, abi.ArgumentName(inputs_args, i), inputs_args[i].Type.String())
+		}
+		if text != inputsMap[i] {
+			w.Amounts = append(w.Amounts, fmt.Sprintf(templates.AmountResult, abi.ArgumentName(inputs_args, i), strings.TrimSpace(inputsMap[i]), text))
+		}
 
-			for _, big_value := range result_array {
-				bi := new(big.Int)
-				bi, _ = bi.SetString(big_value, 10)
-				if bi == nil {
-					return nil, errors.New("incorrect inputs: " + arg_value + " not " + inputs_args[i].Type.String())
-				}
-				result = append(result, bi)
-			}
-			inputs_interfaces = append(inputs_interfaces, result)
+		value, err := ParseValue(inputs_args[i].Type, text)
+		if err != nil {
+			return nil, errors.Wrapf(err, "argument %d (%s %s)", i, abi.ArgumentName(inputs_args, i), inputs_args[i].Type.String())
 		}
+		inputs_interfaces = append(inputs_interfaces, value)
 	}
 
 	return inputs_interfaces, nil
 }
 
-func (w *EthWorker) ParseOutput(outputs []interface{}) (string, error) {
-
-	if len(Containers.Containers[w.Container].Contracts[w.Contract].Abi.Methods[w.Endpoint].Outputs) == 0 {
-		return "", nil
+// parseAmount converts an integer input given with a unit suffix, such as
+// `1.5 ether` or `12.5 tokens`, into its raw value. Other inputs are returned
+// as they are.
+func (w *EthWorker) parseAmount(t abi.Type, text string) (string, error) {
+	if (t.T != abi.IntTy && t.T != abi.UintTy) || !HasUnit(text) {
+		return text, nil
 	}
 
-	if Containers.Containers[w.Container] == nil || Containers.Containers[w.Container].Contracts[w.Contract] == nil {
-		return "", errors.New("input values incorrect")
+	decimals := -1
+	if IsTokenAmount(text) {
+		d, err := w.TokenDecimals()
+		if err != nil {
+			return "", err
+		}
+		decimals = d
 	}
 
-	if len(Containers.Containers[w.Container].Contracts[w.Contract].Abi.Methods[w.Endpoint].Outputs) != 0 && Containers.Containers[w.Container].Contracts[w.Contract].OutputsInterfaces[w.Endpoint] == nil {
-		return "", errors.New("input values incorrect")
+	amount, err := ParseAmount(text, decimals)
+	if err != nil {
+		return "", err
 	}
+	return amount.String(), nil
+}
 
-	output_args := Containers.Containers[w.Container].Contracts[w.Contract].Abi.Methods[w.Endpoint].Outputs
-
-	if len(outputs) != len(output_args) {
-		return "", errors.New("incorrect inputs")
+// TokenDecimals returns the result of the decimals() method of the contract,
+// or -1 if the contract has no such method.
+func (w *EthWorker) TokenDecimals() (int, error) {
+	method, ok := w.contract().Abi.Methods["decimals"]
+	if !ok || len(method.Inputs) != 0 || len(method.Outputs) != 1 {
+		return -1, nil
+	}
+	if t := method.Outputs[0].Type.T; t != abi.UintTy && t != abi.IntTy {
+		return -1, nil
 	}
 
-	var item_array []string
-
-	for i := 0; i < len(outputs); i++ {
-
-		switch output_args[i].Type.Type.String() {
-		case "bool":
-			item := strconv.FormatBool(*outputs[i].(*bool))
-
-			item_array = append(item_array, item)
+	contract := bind.NewBoundContract(
+		common.HexToAddress(w.ContractAddress),
+		w.contract().Abi,
+		Client,
+		Client,
+		Client,
+	)
 
-		case "[]bool":
-			boolArray := *outputs[i].(*[]bool)
-			var boolItems []string
+	outputs := newValues(method.Outputs)
+	if err := contract.Call(&bind.CallOpts{Pending: true}, &outputs, "decimals"); err != nil {
+		return -1, errors.Wrap(err, "call decimals")
+	}
 
-			for _, bool_value := range boolArray {
-				item := strconv.FormatBool(bool_value)
-				boolItems = append(boolItems, item)
-			}
-			item := "[ " + strings.Join(boolItems, ",") + " ]"
-			item_array = append(item_array, item)
+	decimals, err := strconv.Atoi(fmt.Sprint(reflect.ValueOf(outputs[0]).Elem().Interface()))
+	if err != nil || decimals < 0 || decimals > 77 {
+		return -1, errors.Errorf("invalid decimals %v", reflect.ValueOf(outputs[0]).Elem().Interface())
+	}
+	return decimals, nil
+}
 
-		case "string":
-			item_array = append(item_array, *outputs[i].(*string))
-		case "[]string":
-			array := *outputs[i].(*[]string)
-			var items []string
+func (w *EthWorker) ParseOutput(outputs []interface{}) ([]Output, error) {
 
-			for _, value := range array {
-				items = append(items, value)
-			}
-			item := "[ " + strings.Join(items, ",") + " ]"
-			item_array = append(item_array, item)
-		case "[]byte":
-			array := *outputs[i].(*[]byte)
-			var items []string
-
-			for _, value := range array {
-				items = append(items, string(value))
-			}
-			item := "[ " + strings.Join(items, ",") + " ]"
-			item_array = append(item_array, item)
-		case "[][]byte":
-			array := *outputs[i].(*[][]byte)
-			var items string
+	current := w.contract()
+	if current == nil {
+		return nil, errors.New("input values incorrect")
+	}
 
-			for _, array2 := range array {
+	if len(current.Abi.Methods[w.Endpoint].Outputs) == 0 {
+		return nil, nil
+	}
 
-				var items2 []string
+	if len(current.Abi.Methods[w.Endpoint].Outputs) != 0 && current.OutputsInterfaces[w.Endpoint] == nil {
+		return nil, errors.New("input values incorrect")
+	}
 
-				for _, value := range array2 {
-					items2 = append(items2, string(value))
-				}
-				item2 := "[ " + strings.Join(items2, ",") + " ]"
-				items = items + "," + item2
-			}
-			item_array = append(item_array, items)
-		case "common.Address":
-			item := *outputs[i].(*common.Address)
+	output_args := current.Abi.Methods[w.Endpoint].Outputs
 
-			item_array = append(item_array, item.String())
+	if len(outputs) != len(output_args) {
+		return nil, errors.New("incorrect inputs")
+	}
 
-		case "[]common.Address":
-			addrArray := *outputs[i].(*[]common.Address)
-			var addrItems []string
+	var result []Output
 
-			for _, value := range addrArray {
-				addrItems = append(addrItems, value.String())
-			}
-			item := "[ " + strings.Join(addrItems, ",") + " ]"
-			item_array = append(item_array, item)
-		case "common.Hash":
-			item := *outputs[i].(*common.Hash)
-
-			item_array = append(item_array, item.String())
-		case "[]common.Hash":
-			hashArray := *outputs[i].(*[]common.Hash)
-			var hashItems []string
-
-			for _, value := range hashArray {
-				hashItems = append(hashItems, value.String())
-			}
-			item := "[ " + strings.Join(hashItems, ",") + " ]"
-			item_array = append(item_array, item)
-		case "int8":
-			item := *outputs[i].(*int8)
-			str := strconv.FormatInt(int64(item), 10)
-			item_array = append(item_array, str)
-		case "int16":
-			item := *outputs[i].(*int16)
-			str := strconv.FormatInt(int64(item), 10)
-			item_array = append(item_array, str)
-		case "int32":
-			item := *outputs[i].(*int32)
-			str := strconv.FormatInt(int64(item), 10)
-			item_array = append(item_array, str)
-		case "int64":
-			item := *outputs[i].(*int64)
-			str := strconv.FormatInt(item, 10)
-			item_array = append(item_array, str)
-		case "uint8":
-			item := *outputs[i].(*uint8)
-			str := strconv.FormatInt(int64(item), 10)
-			item_array = append(item_array, str)
-		case "uint16":
-			item := *outputs[i].(*uint16)
-			str := strconv.FormatInt(int64(item), 10)
-			item_array = append(item_array, str)
-		case "uint32":
-			item := *outputs[i].(*uint32)
-			str := strconv.FormatInt(int64(item), 10)
-			item_array = append(item_array, str)
-		case "uint64":
-			item := *outputs[i].(*uint64)
-			str := strconv.FormatInt(int64(item), 10)
-			item_array = append(item_array, str)
-		case "*big.Int":
-			item := *outputs[i].(**big.Int)
-			item_array = append(item_array, item.String())
-		case "[]*big.Int":
-			bigArray := *outputs[i].(*[]*big.Int)
-			var items []string
-			for _, v := range bigArray {
-				items = append(items, v.String())
-			}
-			item := "[ " + strings.Join(items, ",") + " ]"
-			item_array = append(item_array, item)
-		}
-	}
-	return strings.Join(item_array, " , "), nil
+	for i := 0; i < len(outputs); i++ {
+		value := reflect.ValueOf(outputs[i]).Elem().Interface()
+		result = append(result, Output{
+			Name:  abi.ArgumentName(output_args, i),
+			Type:  output_args[i].Type.String(),
+			Value: JSONValue(output_args[i].Type, value),
+		})
+	}
+	return result, nil
 }
 
 func Bind(dirname, solcfile string) (*ContractContainers, error) {
@@ -750,133 +682,9 @@ func Bind(dirname, solcfile string) (*ContractContainers, error) {
 				}
 				nameParts := strings.Split(name, ":")
 
-				var ab_keys []string
-
-				ouputs_map := make(map[string][]interface{})
-				inputs_map := make(map[string][]interface{})
-
-				for key, method := range ab.Methods {
-					ab_keys = append(ab_keys, key)
-
-					var o []interface{}
-					var i []interface{}
-
-					for _, v := range method.Outputs {
-						var ar interface{}
-
-						switch v.Type.Type.String() {
-						case "bool":
-							ar = new(bool)
-						case "[]bool":
-							ar = new([]bool)
-						case "string":
-							ar = new(string)
-						case "[]string":
-							ar = new([]string)
-						case "[]byte":
-							ar = new([]byte)
-						case "[][]byte":
-							ar = new([][]byte)
-						case "common.Address":
-							ar = new(common.Address)
-						case "[]common.Address":
-							ar = new([]common.Address)
-						case "common.Hash":
-							ar = new(common.Hash)
-						case "[]common.Hash":
-							ar = new([]common.Hash)
-						case "int8":
-							ar = new(int8)
-						case "int16":
-							ar = new(int16)
-						case "int32":
-							ar = new(int32)
-						case "int64":
-							ar = new(int64)
-						case "uint8":
-							ar = new(uint8)
-						case "uint16":
-							ar = new(uint16)
-						case "uint32":
-							ar = new(uint32)
-						case "uint64":
-							ar = new(uint64)
-						case "*big.Int":
-							ar = new(*big.Int)
-						case "[]*big.Int":
-							ar = new([]*big.Int)
-						default:
-							return nil, errors.Errorf("unsupported type: %s", v.Type.Type.String())
-						}
-
-						o = append(o, ar)
-
-					}
-
-					ouputs_map[method.Name] = o
-
-					for _, v := range method.Inputs {
-						var ar interface{}
-
-						switch v.Type.Type.String() {
-						case "bool":
-							ar = new(bool)
-						case "[]bool":
-							ar = new([]bool)
-						case "string":
-							ar = new(string)
-						case "[]string":
-							ar = new([]string)
-						case "[]byte":
-							ar = new([]byte)
-						case "[][]byte":
-							ar = new([][]byte)
-						case "common.Address":
-							ar = new(common.Address)
-						case "[]common.Address":
-							ar = new([]common.Address)
-						case "common.Hash":
-							ar = new(common.Hash)
-						case "[]common.Hash":
-							ar = new([]common.Hash)
-						case "int8":
-							ar = new(int8)
-						case "int16":
-							ar = new(int16)
-						case "int32":
-							ar = new(int32)
-						case "int64":
-							ar = new(int64)
-						case "uint8":
-							ar = new(uint8)
-						case "uint16":
-							ar = new(uint16)
-						case "uint32":
-							ar = new(uint32)
-						case "uint64":
-							ar = new(uint64)
-						case "*big.Int":
-							ar = new(*big.Int)
-						case "[]*big.Int":
-							ar = new([]*big.Int)
-						default:
-							return nil, errors.Errorf("unsupported type: %s", v.Type.Type.String())
-						}
-						i = append(i, ar)
-					}
-
-					inputs_map[method.Name] = i
-				}
-				sort.Strings(ab_keys)
-
-				con := &Contract{
-					Name:              nameParts[len(nameParts)-1],
-					Abi:               ab,
-					AbiJson:           string(a),
-					Bin:               contract.Code,
-					SortKeys:          ab_keys,
-					OutputsInterfaces: ouputs_map,
-					InputsInterfaces:  inputs_map,
+				con, err := NewContract(nameParts[len(nameParts)-1], ab, string(a), contract.Code)
+				if err != nil {
+					return nil, err
 				}
 
 				c.ContractNames = append(c.ContractNames, nameParts[len(nameParts)-1])
@@ -895,6 +703,107 @@ func Bind(dirname, solcfile string) (*ContractContainers, error) {
 
 }
 
+// NewContract prepares a contract for the front-end: it lists its methods and
+// allocates the values their inputs and outputs are unpacked into.
+func NewContract(name string, ab abi.ABI, abi_json, bin string) (*Contract, error) {
+	var ab_keys []string
+
+	ouputs_map := make(map[string][]interface{})
+	inputs_map := make(map[string][]interface{})
+
+	for key, method := range ab.Methods {
+		ab_keys = append(ab_keys, key)
+
+		ouputs_map[method.Name] = newValues(method.Outputs)
+		inputs_map[method.Name] = newValues(method.Inputs)
+	}
+	sort.Strings(ab_keys)
+
+	con := &Contract{
+		Name:              name,
+		Abi:               ab,
+		AbiJson:           abi_json,
+		Bin:               bin,
+		SortKeys:          ab_keys,
+		OutputsInterfaces: ouputs_map,
+		InputsInterfaces:  inputs_map,
+	}
+
+	return con, nil
+}
+
+// newValues allocates a pointer to the Go value of every argument, ready
+// for the arguments to be unpacked into.
+func newValues(args []abi.Argument) []interface{} {
+	var values []interface{}
+	for _, arg := range args {
+		values = append(values, reflect.New(arg.Type.Type).Interface())
+	}
+	return values
+}
+
+// AttachedContainer holds the contracts attached by their human-readable abi
+// rather than compiled from the sol files.
+const AttachedContainer = "attached"
+
+// AttachContract registers a contract known only by human-readable abi
+// fragments, e.g. `function transfer(address to, uint256 amount) returns (bool)`,
+// so that deployed third-party contracts can be used without their source.
+func AttachContract(name, fragments string) error {
+	if name == "" {
+		return errors.New("empty contract name")
+	}
+
+	ab, err := abi.HumanReadable(strings.NewReader(fragments))
+	if err != nil {
+		return errors.Wrap(err, "abi.HumanReadable")
+	}
+	a, err := json.Marshal(ab)
+	if err != nil {
+		return errors.Wrap(err, "json marshal")
+	}
+
+	con, err := NewContract(name, ab, string(a), "")
+	if err != nil {
+		return err
+	}
+
+	ContainersMu.Lock()
+	defer ContainersMu.Unlock()
+
+	// Handlers keep reading their snapshot, so the containers are copied
+	// with the contract attached and swapped in rather than modified
+	next := &ContractContainers{
+		ContainerNames: Containers.ContainerNames,
+		Containers:     make(map[string]*ContractContainer, len(Containers.Containers)+1),
+	}
+	for container_name, container := range Containers.Containers {
+		next.Containers[container_name] = container
+	}
+	c := &ContractContainer{
+		ContainerName: AttachedContainer,
+		Contracts:     make(map[string]*Contract),
+	}
+	if attached := Containers.Containers[AttachedContainer]; attached != nil {
+		c.ContractNames = append(c.ContractNames, attached.ContractNames...)
+		for contract_name, contract := range attached.Contracts {
+			c.Contracts[contract_name] = contract
+		}
+	} else {
+		next.ContainerNames = append(append([]string(nil), Containers.ContainerNames...), AttachedContainer)
+		sort.Strings(next.ContainerNames)
+	}
+	if c.Contracts[name] == nil {
+		c.ContractNames = append(c.ContractNames, name)
+		sort.Strings(c.ContractNames)
+	}
+	c.Contracts[name] = con
+	next.Containers[AttachedContainer] = c
+
+	Containers = next
+	return nil
+}
+
 func hasSuffixCaseInsensitive(s, suffix string) bool {
 	return len(s) >= len(suffix) && strings.ToLower(s[len(s)-len(suffix):]) == suffix
 }
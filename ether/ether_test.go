@@ -5,6 +5,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"strings"
 	"github.com/ethereum/go-ethereum/common"
+	"sync"
 )
 
 func TestEthWorker_Call(t *testing.T) {
@@ -13,4 +14,42 @@ func TestEthWorker_Call(t *testing.T) {
 	private_key := strings.TrimPrefix(common.BigToHash(key.D).String(), "0x")
 	t.Logf("Address: %s", addr.String())
 	t.Logf("Key: %s", private_key)
-}
\ No newline at end of file
+}
+
+func TestAttachContractConcurrent(t *testing.T) {
+	saved := Containers
+	defer func() { Containers = saved }()
+	Containers = &ContractContainers{Containers: make(map[string]*ContractContainer)}
+	empty := LoadContainers()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			name := "Token" + string('A'+rune(i))
+			if err := AttachContract(name, "function balanceOf(address owner) view returns (uint256)"); err != nil {
+				t.Error(err)
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			if c := LoadContainers().Containers[AttachedContainer]; c != nil {
+				for _, name := range c.ContractNames {
+					if c.Contracts[name] == nil {
+						t.Errorf("contract %s is listed but missing", name)
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if names := LoadContainers().Containers[AttachedContainer].ContractNames; len(names) != 8 {
+		t.Errorf("expected 8 attached contracts, got %v", names)
+	}
+	// Attaching swaps in a copy, leaving the snapshots being read untouched
+	if len(empty.Containers) != 0 || len(empty.ContainerNames) != 0 {
+		t.Errorf("snapshot modified by attaching: %v", empty.ContainerNames)
+	}
+}
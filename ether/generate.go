@@ -0,0 +1,154 @@
+package ether
+
+import (
+	"ethereum-front/abi/bind"
+	"github.com/pkg/errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// GenerateOptions configures the binding packages written by Generate.
+type GenerateOptions struct {
+	OutDir    string    // Directory the packages are written into, one per container
+	Package   string    // Package name of every binding; derived from the container if empty
+	Lang      bind.Lang // Language of the bindings
+	Contracts []string  // Contracts to include, all if empty; `Name` or `Container.sol:Name`
+}
+
+// Generate writes a binding package for every container holding selected
+// contracts and returns the paths of the written files. Each container goes
+// to its own directory under OutDir, named after the container. Java
+// bindings are written one file per contract, named after its class.
+func Generate(containers *ContractContainers, opts GenerateOptions) ([]string, error) {
+	wanted := make(map[string]bool)
+	for _, name := range opts.Contracts {
+		if name = strings.TrimSpace(name); name != "" {
+			wanted[name] = false
+		}
+	}
+
+	var written []string
+	for _, container_name := range containers.ContainerNames {
+		container := containers.Containers[container_name]
+
+		var types, abis, bytecodes []string
+		for _, contract_name := range container.ContractNames {
+			if len(wanted) != 0 && !selectContract(wanted, container_name, contract_name) {
+				continue
+			}
+			contract := container.Contracts[contract_name]
+			types = append(types, contract_name)
+			abis = append(abis, contract.AbiJson)
+			bytecodes = append(bytecodes, contract.Bin)
+		}
+		if len(types) == 0 {
+			continue
+		}
+
+		dir := PackageName(container_name)
+		pkg := opts.Package
+		if pkg == "" {
+			pkg = dir
+		}
+		path := filepath.Join(opts.OutDir, dir)
+		if err := os.MkdirAll(path, 0755); err != nil {
+			return written, errors.Wrap(err, "mkdir")
+		}
+		// Java allows a single public class per file, so every contract gets its own
+		if opts.Lang == bind.LangJava {
+			for i := range types {
+				file := filepath.Join(path, strings.ToUpper(types[i][:1])+types[i][1:]+".java")
+				if err := writeBinding(file, types[i:i+1], abis[i:i+1], bytecodes[i:i+1], pkg, opts.Lang); err != nil {
+					return written, errors.Wrapf(err, "bind %s:%s", container_name, types[i])
+				}
+				written = append(written, file)
+			}
+			continue
+		}
+		file := filepath.Join(path, dir+bindingExtensions[opts.Lang])
+		if err := writeBinding(file, types, abis, bytecodes, pkg, opts.Lang); err != nil {
+			return written, errors.Wrapf(err, "bind %s", container_name)
+		}
+		written = append(written, file)
+	}
+
+	var missing []string
+	for name, found := range wanted {
+		if !found {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) != 0 {
+		sort.Strings(missing)
+		return written, errors.Errorf("contracts not found: %s", strings.Join(missing, ", "))
+	}
+	return written, nil
+}
+
+// writeBinding generates the binding of the given contracts into a file.
+func writeBinding(file string, types, abis, bytecodes []string, pkg string, lang bind.Lang) error {
+	code, err := bind.Bind(types, abis, bytecodes, pkg, lang)
+	if err != nil {
+		return err
+	}
+	return errors.Wrap(ioutil.WriteFile(file, []byte(code), 0644), "write binding")
+}
+
+// selectContract reports whether a contract is wanted, by its name or its
+// container qualified name, and marks the matching entries as found.
+func selectContract(wanted map[string]bool, container_name, contract_name string) bool {
+	selected := false
+	for _, name := range []string{contract_name, container_name + ":" + contract_name} {
+		if _, ok := wanted[name]; ok {
+			wanted[name] = true
+			selected = true
+		}
+	}
+	return selected
+}
+
+// PackageName derives a package name from a container, e.g. `My-Token.sol`
+// becomes `my_token`.
+func PackageName(container_name string) string {
+	name := container_name
+	if hasSuffixCaseInsensitive(name, ".sol") {
+		name = name[:len(name)-len(".sol")]
+	}
+	name = strings.Map(func(r rune) rune {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return unicode.ToLower(r)
+		}
+		return '_'
+	}, name)
+	switch {
+	case name == "":
+		name = "contract"
+	case unicode.IsDigit(rune(name[0])):
+		name = "contract_" + name
+	}
+	return name
+}
+
+// bindingExtensions maps the binding languages to their source file extensions.
+var bindingExtensions = map[bind.Lang]string{
+	bind.LangGo:         ".go",
+	bind.LangJava:       ".java",
+	bind.LangTypeScript: ".ts",
+}
+
+// ParseLang returns the binding language of the given name.
+func ParseLang(name string) (bind.Lang, error) {
+	switch strings.ToLower(name) {
+	case "go":
+		return bind.LangGo, nil
+	case "java":
+		return bind.LangJava, nil
+	case "typescript", "ts":
+		return bind.LangTypeScript, nil
+	}
+	return 0, errors.Errorf("unsupported binding language: %s", name)
+}
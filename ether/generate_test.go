@@ -0,0 +1,151 @@
+package ether
+
+import (
+	"ethereum-front/abi"
+	"ethereum-front/abi/bind"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const generateABI = `[{"constant":true,"inputs":[],"name":"total","outputs":[{"name":"","type":"uint256"}],"type":"function"}]`
+
+func generateContainers(t *testing.T) *ContractContainers {
+	ab, err := abi.JSON(strings.NewReader(generateABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	containers := &ContractContainers{Containers: make(map[string]*ContractContainer)}
+	for _, container_name := range []string{"Token.sol", "Sale.sol"} {
+		c := &ContractContainer{ContainerName: container_name, Contracts: make(map[string]*Contract)}
+		for _, name := range []string{"Base", strings.TrimSuffix(container_name, ".sol")} {
+			con, err := NewContract(name, ab, generateABI, "0x6060")
+			if err != nil {
+				t.Fatal(err)
+			}
+			c.ContractNames = append(c.ContractNames, name)
+			c.Contracts[name] = con
+		}
+		containers.ContainerNames = append(containers.ContainerNames, container_name)
+		containers.Containers[container_name] = c
+	}
+	return containers
+}
+
+func TestGenerate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "generate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files, err := Generate(generateContainers(t), GenerateOptions{
+		OutDir:    dir,
+		Package:   "contracts",
+		Lang:      bind.LangJava,
+		Contracts: []string{"Token", "Sale.sol:Base"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{filepath.Join(dir, "token", "Token.java"), filepath.Join(dir, "sale", "Base.java")}
+	if strings.Join(files, ",") != strings.Join(want, ",") {
+		t.Fatalf("written files mismatch: have %v, want %v", files, want)
+	}
+
+	token, err := ioutil.ReadFile(want[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(token), "package contracts;") || !strings.Contains(string(token), "public class Token ") {
+		t.Errorf("unexpected token binding:\n%s", token)
+	}
+	if strings.Contains(string(token), "class Base ") {
+		t.Errorf("token binding includes an unselected contract")
+	}
+	sale, err := ioutil.ReadFile(want[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(sale), "public class Base ") || strings.Contains(string(sale), "class Sale ") {
+		t.Errorf("unexpected sale binding:\n%s", sale)
+	}
+
+	// Every contract of a container goes to its own file, as Java allows a single public class per file
+	files, err = Generate(generateContainers(t), GenerateOptions{OutDir: dir, Lang: bind.LangJava, Contracts: []string{"Token.sol:Base", "Token"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = []string{filepath.Join(dir, "token", "Base.java"), filepath.Join(dir, "token", "Token.java")}
+	if strings.Join(files, ",") != strings.Join(want, ",") {
+		t.Fatalf("written files mismatch: have %v, want %v", files, want)
+	}
+	base, err := ioutil.ReadFile(want[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Count(string(base), "public class ") != 1 || !strings.Contains(string(base), "public class Base ") {
+		t.Errorf("unexpected base binding:\n%s", base)
+	}
+
+	_, err = Generate(generateContainers(t), GenerateOptions{OutDir: dir, Lang: bind.LangJava, Contracts: []string{"Token", "Missing"}})
+	if err == nil || err.Error() != "contracts not found: Missing" {
+		t.Errorf("expected missing contract error, got %v", err)
+	}
+}
+
+func TestGenerateTuples(t *testing.T) {
+	dir, err := ioutil.TempDir("", "generate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	const tupleABI = `[{"constant":true,"inputs":[],"name":"get","outputs":[{"name":"","type":"tuple","components":[{"name":"amount","type":"uint256"},{"name":"owner","type":"address"}]}],"type":"function"}]`
+	ab, err := abi.JSON(strings.NewReader(tupleABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	con, err := NewContract("Store", ab, tupleABI, "0x6060")
+	if err != nil {
+		t.Fatal(err)
+	}
+	containers := &ContractContainers{
+		ContainerNames: []string{"Store.sol"},
+		Containers: map[string]*ContractContainer{
+			"Store.sol": {ContainerName: "Store.sol", ContractNames: []string{"Store"}, Contracts: map[string]*Contract{"Store": con}},
+		},
+	}
+
+	files, err := Generate(containers, GenerateOptions{OutDir: dir, Lang: bind.LangGo})
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, err := ioutil.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(code), "type Struct0 struct") || !strings.Contains(string(code), "Get(opts *bind.CallOpts) (Struct0, error)") {
+		t.Errorf("unexpected tuple binding:\n%s", code)
+	}
+
+	_, err = Generate(containers, GenerateOptions{OutDir: dir, Lang: bind.LangJava})
+	if err == nil || !strings.Contains(err.Error(), "Java bindings don't support (uint256,address)") {
+		t.Errorf("expected unsupported tuple error, got %v", err)
+	}
+}
+
+func TestPackageName(t *testing.T) {
+	for container_name, want := range map[string]string{
+		"Token.sol":    "token",
+		"My-Token.SOL": "my_token",
+		"721.sol":      "contract_721",
+		".sol":         "contract",
+	} {
+		if have := PackageName(container_name); have != want {
+			t.Errorf("%s: have %s, want %s", container_name, have, want)
+		}
+	}
+}
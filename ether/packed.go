@@ -0,0 +1,55 @@
+package ether
+
+import (
+	"encoding/json"
+	"ethereum-front/abi"
+	"ethereum-front/templates"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"strings"
+)
+
+// SoliditySHA3 computes keccak256(abi.encodePacked(...)) of values typed in
+// on the front-end. types_list is a comma separated list of abi types, e.g.
+// `address,uint256`, and values a JSON array holding a value per type.
+func SoliditySHA3(types_list, values string) (string, error) {
+	var types []abi.Type
+	for _, kind := range strings.Split(types_list, ",") {
+		typ, err := abi.NewType(strings.TrimSpace(kind))
+		if err != nil {
+			return "", errors.Wrapf(err, "type %s", kind)
+		}
+		types = append(types, typ)
+	}
+
+	dec := json.NewDecoder(strings.NewReader(values))
+	dec.UseNumber()
+
+	var raw []interface{}
+	if err := dec.Decode(&raw); err != nil {
+		return "", errors.Wrap(err, "json decode")
+	}
+	if len(raw) != len(types) {
+		return "", errors.Errorf("%d types but %d values", len(types), len(raw))
+	}
+
+	args := make([]interface{}, len(types))
+	for i, typ := range types {
+		value, err := convertJSONValue(typ, raw[i])
+		if err != nil {
+			return "", errors.Wrapf(err, "value %d", i)
+		}
+		args[i] = value.Interface()
+	}
+
+	packed, err := abi.PackPacked(types, args...)
+	if err != nil {
+		return "", err
+	}
+	hash, err := abi.SoliditySHA3(types, args...)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(templates.PackedResult, hexutil.Encode(packed), hash.Hex()), nil
+}
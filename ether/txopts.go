@@ -0,0 +1,77 @@
+package ether
+
+import (
+	"ethereum-front/abi/bind"
+	"github.com/pkg/errors"
+	"net/url"
+	"strings"
+)
+
+// Form fields overriding the transaction options of a request.
+const (
+	GasLimitField    = "gas_limit"
+	GasPriceField    = "gas_price"
+	NonceField       = "nonce"
+	EstimateGasField = "estimate_gas"
+	BlockField       = "block"
+)
+
+// ApplyTxOptions overrides the gas limit, gas price and nonce of opts with the
+// ones given in the form. The gas price takes a unit suffix, e.g. `20 gwei`.
+// With the estimate gas toggle on the gas limit is left to estimation, i.e.
+// opts.GasLimit is nil. Options not given in the form are left as they are.
+func ApplyTxOptions(values url.Values, opts *bind.TransactOpts) error {
+	text := strings.TrimSpace(values.Get(GasLimitField))
+	switch {
+	case values.Get(EstimateGasField) != "" && text != "":
+		return errors.New("gas limit is given together with estimate gas")
+	case values.Get(EstimateGasField) != "":
+		opts.GasLimit = nil
+	case text != "":
+		gasLimit, err := parseInteger(text)
+		if err != nil || gasLimit.Sign() <= 0 {
+			return errors.Errorf("gas limit %s is not a positive integer", text)
+		}
+		opts.GasLimit = gasLimit
+	}
+
+	if text := strings.TrimSpace(values.Get(GasPriceField)); text != "" {
+		gasPrice, err := ParseAmount(text, -1)
+		if err != nil {
+			return errors.Wrap(err, "gas price")
+		}
+		if gasPrice.Sign() < 0 {
+			return errors.Errorf("gas price %s is negative", text)
+		}
+		opts.GasPrice = gasPrice
+	}
+
+	if text := strings.TrimSpace(values.Get(NonceField)); text != "" {
+		nonce, err := parseInteger(text)
+		if err != nil || nonce.Sign() < 0 || !nonce.IsUint64() {
+			return errors.Errorf("nonce %s is not a valid nonce", text)
+		}
+		opts.Nonce = nonce
+	}
+	return nil
+}
+
+// ApplyCallOptions sets the block a constant method is called at from the
+// form: a block number, `latest` for the latest block or `pending` (the
+// default when left empty) for the pending state.
+func ApplyCallOptions(values url.Values, opts *bind.CallOpts) error {
+	text := strings.TrimSpace(values.Get(BlockField))
+	switch strings.ToLower(text) {
+	case "", "pending":
+		opts.Pending, opts.BlockNumber = true, nil
+	case "latest":
+		opts.Pending, opts.BlockNumber = false, nil
+	default:
+		number, err := parseInteger(text)
+		if err != nil || number.Sign() < 0 {
+			return errors.Errorf("block %s is not a block number", text)
+		}
+		opts.Pending, opts.BlockNumber = false, number
+	}
+	return nil
+}
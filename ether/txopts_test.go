@@ -0,0 +1,80 @@
+package ether
+
+import (
+	"ethereum-front/abi/bind"
+	"fmt"
+	"math/big"
+	"net/url"
+	"testing"
+)
+
+func TestApplyTxOptions(t *testing.T) {
+	opts := &bind.TransactOpts{GasLimit: big.NewInt(4700000)}
+	if err := ApplyTxOptions(url.Values{}, opts); err != nil {
+		t.Fatal(err)
+	}
+	if opts.GasLimit.Int64() != 4700000 || opts.GasPrice != nil || opts.Nonce != nil {
+		t.Errorf("empty form changed the options: %+v", opts)
+	}
+
+	values := url.Values{
+		GasLimitField: {"21000"},
+		GasPriceField: {"20 gwei"},
+		NonceField:    {"7"},
+	}
+	if err := ApplyTxOptions(values, opts); err != nil {
+		t.Fatal(err)
+	}
+	if opts.GasLimit.Int64() != 21000 || opts.GasPrice.Int64() != 20000000000 || opts.Nonce.Int64() != 7 {
+		t.Errorf("unexpected options: %+v", opts)
+	}
+
+	if err := ApplyTxOptions(url.Values{EstimateGasField: {"on"}}, opts); err != nil {
+		t.Fatal(err)
+	}
+	if opts.GasLimit != nil {
+		t.Errorf("expected the gas limit left to estimation, got %v", opts.GasLimit)
+	}
+}
+
+func TestApplyTxOptionsErrors(t *testing.T) {
+	for i, test := range []struct {
+		values url.Values
+		err    string
+	}{
+		{url.Values{GasLimitField: {"0"}}, "gas limit 0 is not a positive integer"},
+		{url.Values{GasLimitField: {"1"}, EstimateGasField: {"on"}}, "gas limit is given together with estimate gas"},
+		{url.Values{GasPriceField: {"-1"}}, "gas price -1 is negative"},
+		{url.Values{NonceField: {"1.5"}}, "nonce 1.5 is not a valid nonce"},
+	} {
+		if err := ApplyTxOptions(test.values, &bind.TransactOpts{}); err == nil || err.Error() != test.err {
+			t.Errorf("%d: expected err %q, got %v", i, test.err, err)
+		}
+	}
+}
+
+func TestApplyCallOptions(t *testing.T) {
+	for i, test := range []struct {
+		block   string
+		pending bool
+		number  string
+	}{
+		{"", true, "<nil>"},
+		{"pending", true, "<nil>"},
+		{"latest", false, "<nil>"},
+		{"12", false, "12"},
+		{"0x10", false, "16"},
+	} {
+		opts := new(bind.CallOpts)
+		if err := ApplyCallOptions(url.Values{BlockField: {test.block}}, opts); err != nil {
+			t.Errorf("%d: %v", i, err)
+			continue
+		}
+		if opts.Pending != test.pending || fmt.Sprint(opts.BlockNumber) != test.number {
+			t.Errorf("%d: expected pending %v at %s, got %v at %v", i, test.pending, test.number, opts.Pending, opts.BlockNumber)
+		}
+	}
+	if err := ApplyCallOptions(url.Values{BlockField: {"-1"}}, new(bind.CallOpts)); err == nil {
+		t.Error("expected an error for a negative block")
+	}
+}
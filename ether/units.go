@@ -0,0 +1,80 @@
+package ether
+
+import (
+	"ethereum-front/abi"
+	"github.com/pkg/errors"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+// EtherUnits maps the ether denominations to their decimals relative to wei.
+var EtherUnits = map[string]int{
+	"wei":    0,
+	"kwei":   3,
+	"mwei":   6,
+	"gwei":   9,
+	"szabo":  12,
+	"finney": 15,
+	"ether":  18,
+	"eth":    18,
+}
+
+// amountSuffix splits an amount such as `1.5 ether` or `20gwei` into its
+// number and unit.
+var amountSuffix = regexp.MustCompile(`^([-+]?[0-9]*\.?[0-9]+)\s*([A-Za-z]+)$`)
+
+// splitAmount returns the number and lower cased unit of an amount, if text
+// ends in a known unit.
+func splitAmount(text string) (number, unit string, ok bool) {
+	match := amountSuffix.FindStringSubmatch(strings.TrimSpace(text))
+	if match == nil {
+		return "", "", false
+	}
+	unit = strings.ToLower(match[2])
+	if _, ok := EtherUnits[unit]; !ok && !isTokenUnit(unit) {
+		return "", "", false
+	}
+	return match[1], unit, true
+}
+
+func isTokenUnit(unit string) bool {
+	return unit == "token" || unit == "tokens"
+}
+
+// HasUnit reports whether text is an amount with a unit suffix.
+func HasUnit(text string) bool {
+	_, _, ok := splitAmount(text)
+	return ok
+}
+
+// IsTokenAmount reports whether text is an amount of tokens, e.g. `12.5 tokens`.
+func IsTokenAmount(text string) bool {
+	_, unit, ok := splitAmount(text)
+	return ok && isTokenUnit(unit)
+}
+
+// ParseAmount parses an amount into its raw integer value. Amounts take an
+// ether unit (`1.5 ether`, `20 gwei`, `100 wei`) or are token amounts scaled
+// by decimals (`12.5 tokens`); a negative decimals means the contract has no
+// decimals(). Plain integers are taken as raw values.
+func ParseAmount(text string, decimals int) (*big.Int, error) {
+	number, unit, ok := splitAmount(text)
+	if !ok {
+		return parseInteger(strings.TrimSpace(text))
+	}
+
+	scale, ok := EtherUnits[unit]
+	if !ok {
+		if decimals < 0 {
+			return nil, errors.Errorf("%s: contract has no decimals()", text)
+		}
+		scale = decimals
+	}
+
+	amount, err := abi.ParseFixed(number, scale)
+	if err != nil {
+		return nil, errors.Errorf("%s: %s", text, strings.TrimPrefix(err.Error(), "abi: "))
+	}
+	return amount.Value, nil
+}
@@ -0,0 +1,88 @@
+package ether
+
+import (
+	"ethereum-front/abi"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"net/url"
+	"testing"
+)
+
+func TestParseAmount(t *testing.T) {
+	for i, test := range []struct {
+		input    string
+		decimals int
+		want     string
+	}{
+		{"100", -1, "100"},
+		{"100 wei", -1, "100"},
+		{"20 gwei", -1, "20000000000"},
+		{"1.5 ether", -1, "1500000000000000000"},
+		{"1.5ETH", -1, "1500000000000000000"},
+		{"12.5 tokens", 6, "12500000"},
+		{"1 token", 0, "1"},
+		{"0x10", -1, "16"},
+	} {
+		got, err := ParseAmount(test.input, test.decimals)
+		if err != nil {
+			t.Errorf("%d: %v", i, err)
+			continue
+		}
+		if got.String() != test.want {
+			t.Errorf("%d: expected %s, got %s", i, test.want, got)
+		}
+	}
+}
+
+func TestParseAmountErrors(t *testing.T) {
+	for i, test := range []struct {
+		input    string
+		decimals int
+		err      string
+	}{
+		{"1.5 wei", -1, `1.5 wei: "1.5" has more than 0 decimals`},
+		{"12.5 tokens", -1, "12.5 tokens: contract has no decimals()"},
+		{"1.005 tokens", 2, `1.005 tokens: "1.005" has more than 2 decimals`},
+		{"1 bitcoin", -1, "1 bitcoin is not an integer"},
+	} {
+		if _, err := ParseAmount(test.input, test.decimals); err == nil || err.Error() != test.err {
+			t.Errorf("%d: expected err %q, got %v", i, test.err, err)
+		}
+	}
+}
+
+func TestWorkerTxValue(t *testing.T) {
+	payable := abi.Method{Name: "deposit", Payable: true}
+	nonpayable := abi.Method{Name: "withdraw"}
+
+	for i, test := range []struct {
+		method abi.Method
+		value  string
+		want   string
+		err    string
+	}{
+		{payable, "", "<nil>", ""},
+		{payable, "0", "<nil>", ""},
+		{payable, "1.5 ether", "1500000000000000000", ""},
+		{nonpayable, "0 wei", "<nil>", ""},
+		{nonpayable, "1", "", "withdraw() is not payable"},
+		{abi.Method{}, "1", "", "constructor is not payable"},
+		{payable, "-1", "", "-1 is negative"},
+	} {
+		w := &EthWorker{FormValues: url.Values{"value": {test.value}}}
+		got, err := w.TxValue(test.method, common.Address{})
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("%d: expected err %q, got %v", i, test.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%d: %v", i, err)
+			continue
+		}
+		if got := fmt.Sprint(got); got != test.want {
+			t.Errorf("%d: expected %s, got %s", i, test.want, got)
+		}
+	}
+}
@@ -2,8 +2,10 @@ package front
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/pkg/errors"
@@ -15,13 +17,16 @@ import (
 	"time"
 
 	"ethereum-front/abi/bind"
+	"ethereum-front/abi/bind/backends"
+	"ethereum-front/eip712"
 	"ethereum-front/ether"
 	"ethereum-front/templates"
-	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
 	"net/url"
 	"strconv"
 )
@@ -74,6 +79,7 @@ func SetCookieHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 func MainPage(w http.ResponseWriter, r *http.Request) {
+	containers := ether.LoadContainers()
 
 	key, err := r.Cookie("private_key")
 	if err != nil || key == nil || key.Value == "" {
@@ -82,13 +88,13 @@ func MainPage(w http.ResponseWriter, r *http.Request) {
 	}
 
 	container, err := r.Cookie("container")
-	if err != nil || container == nil || container.Value == "" || ether.Containers.Containers[container.Value] == nil {
+	if err != nil || container == nil || container.Value == "" || containers.Containers[container.Value] == nil {
 		http.Redirect(w, r, "/upload", http.StatusSeeOther)
 		return
 	}
 
 	contract, err := r.Cookie("contract")
-	if err != nil || contract == nil || contract.Value == "" || ether.Containers.Containers[container.Value].Contracts[contract.Value] == nil {
+	if err != nil || contract == nil || contract.Value == "" || containers.Containers[container.Value].Contracts[contract.Value] == nil {
 		http.Redirect(w, r, "/upload", http.StatusSeeOther)
 		return
 	}
@@ -125,8 +131,8 @@ func MainPage(w http.ResponseWriter, r *http.Request) {
 	t := template.New("Methods")
 	t, _ = t.Parse(templates.MethodTemplate)
 
-	for _, v := range ether.Containers.Containers[container.Value].Contracts[contract.Value].SortKeys {
-		t.Execute(w, ether.Containers.Containers[container.Value].Contracts[contract.Value].Abi.Methods[v])
+	for _, v := range containers.Containers[container.Value].Contracts[contract.Value].SortKeys {
+		t.Execute(w, containers.Containers[container.Value].Contracts[contract.Value].Abi.Methods[v])
 	}
 	t3 := template.New("body2")
 	t3.Parse(templates.FormFinish)
@@ -135,6 +141,8 @@ func MainPage(w http.ResponseWriter, r *http.Request) {
 }
 
 func Private(w http.ResponseWriter, r *http.Request) {
+	containers := ether.LoadContainers()
+
 	r.ParseForm()
 	endpoint := r.Form.Get("endpoint")
 	if endpoint == "" {
@@ -149,13 +157,13 @@ func Private(w http.ResponseWriter, r *http.Request) {
 	}
 
 	container, err := r.Cookie("container")
-	if err != nil || container == nil || container.Value == "" || ether.Containers.Containers[container.Value] == nil {
+	if err != nil || container == nil || container.Value == "" || containers.Containers[container.Value] == nil {
 		http.Redirect(w, r, "/upload", http.StatusSeeOther)
 		return
 	}
 
 	contract, err := r.Cookie("contract")
-	if err != nil || contract == nil || contract.Value == "" || ether.Containers.Containers[container.Value].Contracts[contract.Value] == nil {
+	if err != nil || contract == nil || contract.Value == "" || containers.Containers[container.Value].Contracts[contract.Value] == nil {
 		http.Redirect(w, r, "/upload", http.StatusSeeOther)
 		return
 	}
@@ -199,8 +207,8 @@ func Private(w http.ResponseWriter, r *http.Request) {
 	t := template.New("Methods")
 	t, _ = t.Parse(templates.MethodTemplate)
 
-	for _, v := range ether.Containers.Containers[container.Value].Contracts[contract.Value].SortKeys {
-		t.Execute(w, ether.Containers.Containers[container.Value].Contracts[contract.Value].Abi.Methods[v])
+	for _, v := range containers.Containers[container.Value].Contracts[contract.Value].SortKeys {
+		t.Execute(w, containers.Containers[container.Value].Contracts[contract.Value].Abi.Methods[v])
 	}
 
 	t3 := template.New("body2")
@@ -212,6 +220,8 @@ func Private(w http.ResponseWriter, r *http.Request) {
 }
 
 func Public(w http.ResponseWriter, r *http.Request) {
+	containers := ether.LoadContainers()
+
 	r.ParseForm()
 
 	endpoint := r.Form.Get("endpoint")
@@ -227,13 +237,13 @@ func Public(w http.ResponseWriter, r *http.Request) {
 	}
 
 	container, err := r.Cookie("container")
-	if err != nil || container == nil || container.Value == "" || ether.Containers.Containers[container.Value] == nil {
+	if err != nil || container == nil || container.Value == "" || containers.Containers[container.Value] == nil {
 		http.Redirect(w, r, "/upload", http.StatusSeeOther)
 		return
 	}
 
 	contract, err := r.Cookie("contract")
-	if err != nil || contract == nil || contract.Value == "" || ether.Containers.Containers[container.Value].Contracts[contract.Value] == nil {
+	if err != nil || contract == nil || contract.Value == "" || containers.Containers[container.Value].Contracts[contract.Value] == nil {
 		http.Redirect(w, r, "/upload", http.StatusSeeOther)
 		return
 	}
@@ -258,8 +268,13 @@ func Public(w http.ResponseWriter, r *http.Request) {
 	result, err := reader.Call()
 	if err != nil {
 		responce = fmt.Sprintf("Error: %s", err.Error())
+	} else if encoded, err := json.Marshal(result); err != nil {
+		responce = fmt.Sprintf("Error: encode result: %s", err.Error())
 	} else {
-		responce = fmt.Sprintf("Result: %s", result)
+		responce = fmt.Sprintf("Result: %s", encoded) + strings.Join(reader.Amounts, "")
+		if block := strings.TrimSpace(r.Form.Get(ether.BlockField)); block != "" {
+			responce = fmt.Sprintf("Block %s: %s", block, responce)
+		}
 	}
 
 	info, err := reader.Info()
@@ -270,6 +285,10 @@ func Public(w http.ResponseWriter, r *http.Request) {
 	tInfo.Parse(templates.HeaderContainer)
 	tInfo.Execute(w, info)
 
+	tOutputs := template.New("outputs")
+	tOutputs.Parse(templates.OutputsTemplate)
+	tOutputs.Execute(w, result)
+
 	t2 := template.New("Textarea")
 	t2.Parse(templates.FormStart)
 	t2.Execute(w, endpoint+" : "+responce)
@@ -277,8 +296,8 @@ func Public(w http.ResponseWriter, r *http.Request) {
 	t := template.New("Methods")
 	t, _ = t.Parse(templates.MethodTemplate)
 
-	for _, v := range ether.Containers.Containers[container.Value].Contracts[contract.Value].SortKeys {
-		t.Execute(w, ether.Containers.Containers[container.Value].Contracts[contract.Value].Abi.Methods[v])
+	for _, v := range containers.Containers[container.Value].Contracts[contract.Value].SortKeys {
+		t.Execute(w, containers.Containers[container.Value].Contracts[contract.Value].Abi.Methods[v])
 	}
 
 	t3 := template.New("body2")
@@ -289,6 +308,8 @@ func Public(w http.ResponseWriter, r *http.Request) {
 }
 
 func EthPage(w http.ResponseWriter, r *http.Request) {
+	containers := ether.LoadContainers()
+
 	var result string
 
 	r.ParseForm()
@@ -305,7 +326,7 @@ func EthPage(w http.ResponseWriter, r *http.Request) {
 		log.Println(err.Error())
 		return
 	}
-	auth := bind.NewKeyedTransactor(key)
+	auth := bind.NewKeyedTransactorWithChainID(key, ether.ChainID)
 
 	switch endpoint {
 	case "balance":
@@ -400,6 +421,65 @@ func EthPage(w http.ResponseWriter, r *http.Request) {
 			result = "It does not work on an emulator"
 		}
 
+	case "decode_calldata":
+		data, err := hexutil.Decode(strings.TrimSpace(r.Form.Get("1")))
+		if err != nil {
+			result = "error: " + err.Error()
+			break
+		}
+		calls, err := ether.DecodeCalldata(data)
+		if err != nil {
+			result = "error: " + err.Error()
+			break
+		}
+		result = "Calldata:\n" + calls
+
+	case "solidity_sha3":
+		hash, err := ether.SoliditySHA3(r.Form.Get("1"), r.Form.Get("2"))
+		if err != nil {
+			result = "error: " + err.Error()
+			break
+		}
+		result = hash
+
+	case "sign_typed_data":
+		typed, err := eip712.Parse([]byte(r.Form.Get("1")))
+		if err != nil {
+			result = "error: " + err.Error()
+			break
+		}
+		hash, sig, err := eip712.Sign(typed, key)
+		if err != nil {
+			result = "error: " + err.Error()
+			break
+		}
+		result = fmt.Sprintf(templates.TypedDataResult,
+			hash.Hex(),
+			auth.From.String(),
+			sig.V,
+			sig.R.Hex(),
+			sig.S.Hex(),
+			hexutil.Encode(sig.Bytes()),
+		)
+
+	case "recover_typed_data":
+		typed, err := eip712.Parse([]byte(r.Form.Get("1")))
+		if err != nil {
+			result = "error: " + err.Error()
+			break
+		}
+		sig, err := hexutil.Decode(strings.TrimSpace(r.Form.Get("2")))
+		if err != nil {
+			result = "error: " + err.Error()
+			break
+		}
+		signer, err := eip712.Recover(typed, sig)
+		if err != nil {
+			result = "error: " + err.Error()
+			break
+		}
+		result = "Signer: " + signer.String()
+
 	case "adjusttime":
 		switch v := ether.Client.(type) {
 		case *ethclient.Client:
@@ -432,25 +512,51 @@ func EthPage(w http.ResponseWriter, r *http.Request) {
 		}
 		value := r.Form.Get("2")
 
-		bigValue := new(big.Int)
-		bigValue, _ = bigValue.SetString(value, 10)
+		bigValue, err := ether.ParseAmount(value, -1)
+		if err == nil && bigValue.Sign() < 0 {
+			err = errors.Errorf("%s is negative", value)
+		}
+		if err != nil {
+			result = "error: " + err.Error()
+			break
+		}
 
-		bigGaslimit := new(big.Int)
-		bigGaslimit, _ = bigGaslimit.SetString(ether.GasLimit.String(), 10)
+		to := common.HexToAddress(to_addr)
 
-		gasprice, err := ether.Client.SuggestGasPrice(context.Background())
-		if err != nil {
+		opts := &bind.TransactOpts{GasLimit: new(big.Int).Set(ether.GasLimit)}
+		if err := ether.ApplyTxOptions(r.Form, opts); err != nil {
 			result = "error: " + err.Error()
+			break
 		}
 
-		bigGasprice := new(big.Int)
-		bigGasprice, _ = bigGasprice.SetString(gasprice.String(), 10)
+		if opts.GasLimit == nil {
+			opts.GasLimit, err = ether.Client.EstimateGas(context.Background(), ethereum.CallMsg{From: auth.From, To: &to, Value: bigValue})
+			if err != nil {
+				result = "error: " + errors.Wrap(err, "estimate gas").Error()
+				break
+			}
+		}
 
-		nonce, err := ether.Client.PendingNonceAt(context.Background(), auth.From)
+		if opts.GasPrice == nil {
+			opts.GasPrice, err = ether.Client.SuggestGasPrice(context.Background())
+			if err != nil {
+				result = "error: " + err.Error()
+				break
+			}
+		}
 
-		rawTx := types.NewTransaction(uint64(nonce), common.HexToAddress(to_addr), bigValue, bigGaslimit, bigGasprice, nil)
+		if opts.Nonce == nil {
+			nonce, err := ether.Client.PendingNonceAt(context.Background(), auth.From)
+			if err != nil {
+				result = "error: " + err.Error()
+				break
+			}
+			opts.Nonce = new(big.Int).SetUint64(nonce)
+		}
 
-		signedTx, err := auth.Signer(types.HomesteadSigner{}, auth.From, rawTx)
+		rawTx := types.NewTransaction(opts.Nonce.Uint64(), to, bigValue, opts.GasLimit, opts.GasPrice, nil)
+
+		signedTx, err := auth.Signer(bind.ChainSigner(auth.ChainID), auth.From, rawTx)
 		if err != nil {
 			result = "error: " + err.Error()
 		}
@@ -488,17 +594,20 @@ func EthPage(w http.ResponseWriter, r *http.Request) {
 				receipt.Status,
 				receipt.TxHash.String(),
 			)
+			if ether.HasUnit(value) {
+				result += fmt.Sprintf(templates.AmountResult, "value", strings.TrimSpace(value), bigValue.String())
+			}
 		}
 	}
 
 	container, err := r.Cookie("container")
-	if err != nil || container == nil || container.Value == "" || ether.Containers.Containers[container.Value] == nil {
+	if err != nil || container == nil || container.Value == "" || containers.Containers[container.Value] == nil {
 		http.Redirect(w, r, "/upload", http.StatusSeeOther)
 		return
 	}
 
 	contract, err := r.Cookie("contract")
-	if err != nil || contract == nil || contract.Value == "" || ether.Containers.Containers[container.Value].Contracts[contract.Value] == nil {
+	if err != nil || contract == nil || contract.Value == "" || containers.Containers[container.Value].Contracts[contract.Value] == nil {
 		http.Redirect(w, r, "/upload", http.StatusSeeOther)
 		return
 	}
@@ -542,8 +651,8 @@ func EthPage(w http.ResponseWriter, r *http.Request) {
 	t := template.New("Methods")
 	t, _ = t.Parse(templates.MethodTemplate)
 
-	for _, v := range ether.Containers.Containers[container.Value].Contracts[contract.Value].SortKeys {
-		t.Execute(w, ether.Containers.Containers[container.Value].Contracts[contract.Value].Abi.Methods[v])
+	for _, v := range containers.Containers[container.Value].Contracts[contract.Value].SortKeys {
+		t.Execute(w, containers.Containers[container.Value].Contracts[contract.Value].Abi.Methods[v])
 	}
 
 	t3 := template.New("body2")
@@ -560,15 +669,39 @@ func Login(w http.ResponseWriter, r *http.Request) {
 
 func Upload(w http.ResponseWriter, r *http.Request) {
 	var container string
+	var attach_error string
 
 	if r.Method == "POST" {
 		r.ParseForm()
+
+		if signatures := r.Form.Get("signatures"); signatures != "" {
+			name := r.Form.Get("attach_name")
+			address := r.Form.Get("attach_address")
+
+			if !common.IsHexAddress(address) {
+				attach_error = address + " : is not address"
+			} else if err := ether.AttachContract(name, signatures); err != nil {
+				attach_error = "attach error: " + err.Error()
+			} else {
+				http.SetCookie(w, &http.Cookie{Name: "container", Value: ether.AttachedContainer})
+				http.SetCookie(w, &http.Cookie{Name: "contract", Value: name})
+				http.SetCookie(w, &http.Cookie{Name: "address", Value: address})
+				http.Redirect(w, r, "/", http.StatusSeeOther)
+				return
+			}
+		}
+	}
+
+	// Attaching is done, the rest reads a snapshot of the contracts
+	containers := ether.LoadContainers()
+
+	if r.Method == "POST" {
 		contract := r.Form.Get("contract")
 		container = r.Form.Get("container")
 		address := r.Form.Get("address")
 		deploy := r.Form.Get("deploy")
 
-		if container != "" && ether.Containers.Containers[container] != nil {
+		if container != "" && containers.Containers[container] != nil {
 			cookie := &http.Cookie{Name: "container", Value: container}
 			http.SetCookie(w, cookie)
 		}
@@ -579,7 +712,7 @@ func Upload(w http.ResponseWriter, r *http.Request) {
 				http.Redirect(w, r, "/upload", http.StatusSeeOther)
 				return
 			}
-			if ether.Containers.Containers[c.Value].Contracts[contract] != nil {
+			if containers.Containers[c.Value].Contracts[contract] != nil {
 				cookie := &http.Cookie{Name: "contract", Value: contract}
 				http.SetCookie(w, cookie)
 			}
@@ -610,17 +743,23 @@ func Upload(w http.ResponseWriter, r *http.Request) {
 	fmt.Fprint(w, templates.PageTemplateHeader)
 	t0 := template.New("container")
 	t0.Parse(templates.SelectContainer)
-	t0.Execute(w, ether.Containers.ContainerNames)
+	t0.Execute(w, containers.ContainerNames)
 
-	if container != "" && ether.Containers.Containers[container] != nil {
+	if container != "" && containers.Containers[container] != nil {
 		t1 := template.New("contract")
 		t1.Parse(templates.SelectContract)
-		t1.Execute(w, ether.Containers.Containers[container].ContractNames)
+		t1.Execute(w, containers.Containers[container].ContractNames)
 	}
+
+	t2 := template.New("attach")
+	t2.Parse(templates.AttachContract)
+	t2.Execute(w, attach_error)
 	fmt.Fprint(w, templates.PageTemplateFutter)
 }
 
 func Deploy(w http.ResponseWriter, r *http.Request) {
+	containers := ether.LoadContainers()
+
 	c1, err := r.Cookie("container")
 	if err != nil || c1 == nil || c1.Value == "" {
 		r.Method = "GET"
@@ -669,8 +808,8 @@ func Deploy(w http.ResponseWriter, r *http.Request) {
 		t := template.New("Methods")
 		t, _ = t.Parse(templates.MethodTemplate)
 
-		for _, v := range ether.Containers.Containers[c1.Value].Contracts[c2.Value].SortKeys {
-			t.Execute(w, ether.Containers.Containers[c1.Value].Contracts[c2.Value].Abi.Methods[v])
+		for _, v := range containers.Containers[c1.Value].Contracts[c2.Value].SortKeys {
+			t.Execute(w, containers.Containers[c1.Value].Contracts[c2.Value].Abi.Methods[v])
 		}
 
 		t3 := template.New("body2")
@@ -683,11 +822,11 @@ func Deploy(w http.ResponseWriter, r *http.Request) {
 
 	t := template.New("Constructor")
 	t, _ = t.Parse(templates.DeployTemplate)
-	t.Execute(w, ether.Containers.Containers[c1.Value].Contracts[c2.Value].Abi.Constructor)
+	t.Execute(w, containers.Containers[c1.Value].Contracts[c2.Value].Abi.Constructor)
 
 }
 
-func Start(connect_url, sol_path, keystore_path string, port int, gaslimit int64, solc string) {
+func Start(connect_url, sol_path, keystore_path string, port int, gaslimit int64, solc string, chain_id int64) {
 
 	ether.GasLimit = big.NewInt(gaslimit)
 	params.GenesisGasLimit = big.NewInt(gaslimit)
@@ -711,15 +850,28 @@ func Start(connect_url, sol_path, keystore_path string, port int, gaslimit int64
 			auth, _ := bind.NewTransactor(strings.NewReader(string(jsonAcc)), "")
 			alloc[auth.From] = core.GenesisAccount{Balance: b1}
 		}
-		ether.Client = backends.NewSimulatedBackend(alloc)
+		var sim *backends.SimulatedBackend
+		if chain_id != 0 {
+			sim = backends.NewSimulatedBackendWithChainID(alloc, big.NewInt(chain_id))
+		} else {
+			sim = backends.NewSimulatedBackend(alloc)
+		}
+		ether.Client = sim
+		ether.ChainID = sim.ChainID()
 
 	} else {
-		conn, err := ethclient.Dial(connect_url)
+		client, err := rpc.Dial(connect_url)
+		if err != nil {
+			panic(err.Error())
+		}
+		ether.Client = ethclient.NewClient(client)
+
+		ether.ChainID, err = ether.DetectChainID(client)
 		if err != nil {
 			panic(err.Error())
 		}
-		ether.Client = conn
 	}
+	log.Printf("chain id: %s\n", ether.ChainID)
 
 	c, err := ether.Bind(sol_path, solc)
 	if err != nil {
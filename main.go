@@ -1,10 +1,13 @@
 package main
 
 import (
+	"ethereum-front/ether"
 	"ethereum-front/front"
 	"flag"
 	"fmt"
 	"github.com/spf13/viper"
+	"os"
+	"strings"
 )
 
 var config_dir string
@@ -16,6 +19,10 @@ func main() {
 		"/app/confdir/",
 		`example: -config=/app/confdir/`,
 	)
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: %s [-config=dir] [generate [options]]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
 	flag.Parse()
 
 	viper.SetConfigName("config")
@@ -39,6 +46,53 @@ func main() {
 	fmt.Printf("gas limit: %d\n", gaslimit)
 	solc := viper.GetString("solc")
 	fmt.Printf("solc file: %s\n", solc)
+	chain_id := viper.GetInt64("chain_id")
+	fmt.Printf("chain id: %d\n", chain_id)
+
+	if flag.Arg(0) == "generate" {
+		generate(sol_path, solc, flag.Args()[1:])
+		return
+	}
+
+	front.Start(connect, sol_path, keystore_path, port, gaslimit, solc, chain_id)
+}
+
+// generate compiles the sol files and writes the binding packages of their
+// contracts, e.g. `generate -out=bindings -lang=go -contracts=Token,Crowdsale`.
+func generate(sol_path, solc string, args []string) {
+	flags := flag.NewFlagSet("generate", flag.ExitOnError)
+	out := flags.String("out", "bindings", "output directory, a package per sol file")
+	pkg := flags.String("pkg", "", "package name of the bindings (default: derived from the sol file)")
+	lang := flags.String("lang", "go", "binding language: go, java or typescript")
+	contracts := flags.String("contracts", "", "comma separated contracts to include, `Name` or `File.sol:Name` (default: all)")
+	flags.Parse(args)
+
+	binding_lang, err := ether.ParseLang(*lang)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
 
-	front.Start(connect, sol_path, keystore_path, port, gaslimit, solc)
+	containers, err := ether.Bind(sol_path, solc)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Fatal error compiling %s: %s\n", sol_path, err)
+		os.Exit(1)
+	}
+
+	opts := ether.GenerateOptions{
+		OutDir:  *out,
+		Package: *pkg,
+		Lang:    binding_lang,
+	}
+	if *contracts != "" {
+		opts.Contracts = strings.Split(*contracts, ",")
+	}
+	files, err := ether.Generate(containers, opts)
+	for _, file := range files {
+		fmt.Printf("wrote %s\n", file)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Fatal error generating bindings: %s\n", err)
+		os.Exit(1)
+	}
 }
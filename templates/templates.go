@@ -111,6 +111,10 @@ const (
 				<td>balance:</td>
 				<td>{{.EthBalance}}</td>
 			</tr>
+			<tr>
+				<td>chain id:</td>
+				<td>{{.ChainID}}</td>
+			</tr>
 			<tr>
 				<td>file:</td>
 				<td>{{.Container}}</td>
@@ -162,6 +166,19 @@ const (
 	    <p><input type="submit" value="Send"></p>
   </form>
 </div>
+`
+
+	AttachContract = `
+<div class="brd">
+	<form action="/upload" method="post">
+		<p>Attach Contract</p>
+		<p><input type="text" name="attach_name" title="contract name" placeholder="contract name"></p>
+		<p><input type="text" name="attach_address" title="contract address" placeholder="contract address"></p>
+		<p><textarea name="signatures" rows="8" cols="80" title="human-readable abi, one fragment per line" placeholder="function transfer(address to, uint256 amount) returns (bool)"></textarea></p>
+		{{if .}}<p>{{.}}</p>{{end}}
+		<p><input type="submit" value="Attach"></p>
+	</form>
+</div>
 `
 
 	DeployTemplate = `
@@ -176,10 +193,42 @@ const (
 			{{end}}
 			{{end}}
 		{{end}}
+	{{if .IsPayable}}
+	<p>
+	<input type="text" name="value" title="value in wei, or with a unit: 1.5 ether" placeholder="value: 100 wei, 1.5 ether">
+	</p>
+	{{end}}
+	<p>
+	<input type="text" name="gas_limit" title="gas limit, default from config" placeholder="gas limit">
+	<input type="text" name="gas_price" title="gas price in wei, or with a unit: 20 gwei" placeholder="gas price">
+	<input type="text" name="nonce" title="nonce, default pending nonce" placeholder="nonce">
+	<input type="checkbox" name="estimate_gas" title="estimate the gas limit"> estimate gas
+	</p>
 
 	<p><input type="submit" value="deploy contract" title="deploy contract"></p>
 </form>
 </div>
+`
+
+	OutputsTemplate = `
+{{if .}}
+<div>
+	<table id="outputs">
+	<tr>
+		<th>name</th>
+		<th>type</th>
+		<th>value</th>
+	</tr>
+	{{range .}}
+	<tr>
+		<td>{{.Name}}</td>
+		<td>{{.Type}}</td>
+		<td>{{.String}}</td>
+	</tr>
+	{{end}}
+	</table>
+</div>
+{{end}}
 `
 
 	FormStart = `
@@ -250,11 +299,53 @@ const (
 							<td>Transfer</td>
 							<td>
 								<input type="text" name="1" title="to address" placeholder="to address">
-								<input type="text" name="2" title="value uint256" placeholder="value uint256">
+								<input type="text" name="2" title="value in wei, or with a unit: 1.5 ether, 20 gwei" placeholder="value: 100 wei, 1.5 ether">
+								<input type="text" name="gas_limit" title="gas limit, default from config" placeholder="gas limit">
+								<input type="text" name="gas_price" title="gas price in wei, or with a unit: 20 gwei" placeholder="gas price">
+								<input type="text" name="nonce" title="nonce, default pending nonce" placeholder="nonce">
+								<input type="checkbox" name="estimate_gas" title="estimate the gas limit"> estimate gas
 							</td>
 							<td><input type="submit" value="transfer"></td>
 						</form>
 					</tr>
+					<tr>
+						<form action="/eth?endpoint=decode_calldata" method="post">
+							<td>Decode calldata</td>
+							<td>
+								<input type="text" name="1" title="transaction input bytes" placeholder="0x calldata">
+							</td>
+							<td><input type="submit" value="decode"></td>
+						</form>
+					</tr>
+					<tr>
+						<form action="/eth?endpoint=solidity_sha3" method="post">
+							<td>keccak256(abi.encodePacked)</td>
+							<td>
+								<input type="text" name="1" title="comma separated types" placeholder="address,uint256,string">
+								<input type="text" name="2" title="json array of values" placeholder='["0x..", 10, "text"]'>
+							</td>
+							<td><input type="submit" value="hash"></td>
+						</form>
+					</tr>
+					<tr>
+						<form action="/eth?endpoint=sign_typed_data" method="post">
+							<td>Sign typed data (EIP-712)</td>
+							<td>
+								<textarea name="1" title="typed data json" placeholder="typed data json"></textarea>
+							</td>
+							<td><input type="submit" value="sign"></td>
+						</form>
+					</tr>
+					<tr>
+						<form action="/eth?endpoint=recover_typed_data" method="post">
+							<td>Recover typed data signer</td>
+							<td>
+								<textarea name="1" title="typed data json" placeholder="typed data json"></textarea>
+								<input type="text" name="2" title="signature" placeholder="0x signature">
+							</td>
+							<td><input type="submit" value="recover"></td>
+						</form>
+					</tr>
 					<tr>
 						<form action="/eth?endpoint=adjusttime" method="post">
 							<td>Adjust time</td>
@@ -273,7 +364,7 @@ const (
 
 	MethodTemplate = `
 <tr>
-<form action="/{{if .Const}}public{{else}}private{{end}}?endpoint={{.Name}}" method="post">
+<form action="/{{if .IsConstant}}public{{else}}private{{end}}?endpoint={{.Name}}" method="post">
 	<td>{{.Sig}}:</td>
 
 	<td>{{if .Inputs}}
@@ -283,6 +374,17 @@ const (
 			{{end}}
 			{{end}}
 		{{end}}
+		{{if .IsPayable}}
+			<input type="text" name="value" title="value in wei, or with a unit: 1.5 ether" placeholder="value: 100 wei, 1.5 ether">
+		{{end}}
+		{{if .IsConstant}}
+			<input type="text" name="block" title="block number or latest, the pending state by default" placeholder="block">
+		{{else}}
+			<input type="text" name="gas_limit" title="gas limit, default from config" placeholder="gas limit">
+			<input type="text" name="gas_price" title="gas price in wei, or with a unit: 20 gwei" placeholder="gas price">
+			<input type="text" name="nonce" title="nonce, default pending nonce" placeholder="nonce">
+			<input type="checkbox" name="estimate_gas" title="estimate the gas limit"> estimate gas
+		{{end}}
 	</td>
 	<td><input type="submit" value={{.Name}} title="{{.String}}"></td>
 </form>
@@ -333,6 +435,12 @@ Cost/Fee: %s
 Status: %d
 Transaction Hash: %s`
 
+	EventResult = `
+Event %d: %s (contract %s)`
+
+	RevertResult = `
+Revert reason: %s`
+
 	DeployResult = `Nonce %d:
 From: %s
 Contract Address: %s
@@ -341,4 +449,17 @@ Gas Used: %s
 Cost/Fee: %s
 Status: %d
 Transaction Hash: %s`
+
+	AmountResult = `
+Amount %s: %s = %s`
+
+	PackedResult = `Packed: %s
+Keccak256: %s`
+
+	TypedDataResult = `Digest: %s
+Signer: %s
+v: %d
+r: %s
+s: %s
+Signature: %s`
 )